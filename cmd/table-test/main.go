@@ -131,7 +131,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	p := tea.NewProgram(f, tea.WithAltScreen())
+	p := tea.NewProgram(f, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 	if _, err := p.Run(); err != nil {
 		fmt.Println("Error running program:", err)
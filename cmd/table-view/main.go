@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"github.com/Funkit/theiere/frame"
+	"github.com/Funkit/theiere/subtable"
+	tea "github.com/charmbracelet/bubbletea"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Println("Usage: table-view <file.csv|file.tsv|file.json>")
+		os.Exit(1)
+	}
+
+	file, err := os.Open(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var t subtable.Model
+	switch strings.ToLower(filepath.Ext(os.Args[1])) {
+	case ".json":
+		t, err = subtable.FromJSON(file, subtable.WithHorizontalScroll())
+	case ".tsv":
+		t, err = subtable.FromTSV(file, subtable.WithHorizontalScroll())
+	default:
+		t, err = subtable.FromCSV(file, subtable.WithHorizontalScroll())
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := frame.New(frame.WithComponent(&t), frame.WithBorder())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p := tea.NewProgram(f, tea.WithAltScreen(), tea.WithMouseCellMotion())
+
+	if _, err := p.Run(); err != nil {
+		fmt.Println("Error running program:", err)
+		os.Exit(1)
+	}
+}
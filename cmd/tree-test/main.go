@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"github.com/Funkit/theiere/frame"
+	"github.com/Funkit/theiere/tree"
+	tea "github.com/charmbracelet/bubbletea"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	root, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	t, err := tree.New([]*tree.Node{directoryNode(root)},
+		tree.WithLoader(loadDirectory),
+		tree.WithIcons(map[string]string{"dir": "▣", "file": "▢"}),
+		tree.WithMultiSelect())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := frame.New(frame.WithComponent(&t), frame.WithBorder())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p := tea.NewProgram(f, tea.WithAltScreen())
+
+	if _, err := p.Run(); err != nil {
+		fmt.Println("Error running program:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Thank you for using this tool !")
+}
+
+func directoryNode(path string) *tree.Node {
+	return &tree.Node{
+		ID:          path,
+		Title:       filepath.Base(path),
+		Kind:        "dir",
+		HasChildren: true,
+	}
+}
+
+func loadDirectory(node *tree.Node) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := os.ReadDir(node.ID)
+		if err != nil {
+			return tree.ChildrenLoaded{ID: node.ID, Err: err}
+		}
+
+		var children []*tree.Node
+		for _, entry := range entries {
+			path := filepath.Join(node.ID, entry.Name())
+			if entry.IsDir() {
+				children = append(children, directoryNode(path))
+				continue
+			}
+			children = append(children, &tree.Node{ID: path, Title: entry.Name(), Kind: "file"})
+		}
+
+		return tree.ChildrenLoaded{ID: node.ID, Children: children}
+	}
+}
@@ -1,7 +1,10 @@
 package executor
 
 import (
+	"github.com/Funkit/theiere/keys"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -20,6 +23,9 @@ type Model struct {
 	GotResult         bool
 	success           bool
 	resultDescription string
+	KeyMap            KeyMap
+	customSuccess     bool
+	customFail        bool
 }
 
 type options struct {
@@ -53,38 +59,45 @@ func New(opts ...Option) (Model, error) {
 		}
 	}
 
+	t := theme.Current()
+
 	successStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
-		Background(lipgloss.Color("#6AA84F"))
+		Foreground(t.Contrast).
+		Background(t.Success)
 	if options.successStyle != nil {
 		successStyle = *options.successStyle
 	}
 
 	failStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
-		Background(lipgloss.Color("#F44336"))
+		Foreground(t.Contrast).
+		Background(t.Danger)
 	if options.failStyle != nil {
 		failStyle = *options.failStyle
 	}
 
 	return Model{
-		spinner:      spinner.New(),
-		successStyle: successStyle,
-		failStyle:    failStyle,
+		spinner:       spinner.New(),
+		KeyMap:        DefaultKeyMap(),
+		successStyle:  successStyle,
+		failStyle:     failStyle,
+		customSuccess: options.successStyle != nil,
+		customFail:    options.failStyle != nil,
 	}, nil
 }
 
 func (m *Model) Init() tea.Cmd {
+	if theme.CurrentAccess().ReducedMotion {
+		return nil
+	}
 	return m.spinner.Tick
 }
 
 func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc":
+		if key.Matches(msg, m.KeyMap.Back) {
 			return m, subview.GoUp
 		}
 	case Message:
@@ -101,6 +114,9 @@ func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
 
 func (m *Model) View() string {
 	if !m.GotResult {
+		if theme.CurrentAccess().ReducedMotion {
+			return "Processing in progress..."
+		}
 		return m.spinner.View() + " Processing in progress..."
 	}
 	if m.success {
@@ -125,3 +141,22 @@ func (m *Model) SetHeight(height int) {
 func (m *Model) Reset() {
 	m.success = false
 }
+
+// DeclareKeys declares the binding going back.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.AddKeyMap(owner, keys.Component, m.KeyMap)
+}
+
+func (m *Model) KeyHelp() []keys.Group {
+	return []keys.Group{{Title: "Execution", Bindings: m.KeyMap.ShortHelp()}}
+}
+
+// SetTheme applies a theme to the result styles, unless they were set with WithSuccessStyle and WithFailStyle.
+func (m *Model) SetTheme(t theme.Theme) {
+	if !m.customSuccess {
+		m.successStyle = m.successStyle.Copy().Foreground(t.Contrast).Background(t.Success)
+	}
+	if !m.customFail {
+		m.failStyle = m.failStyle.Copy().Foreground(t.Contrast).Background(t.Danger)
+	}
+}
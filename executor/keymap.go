@@ -0,0 +1,37 @@
+package executor
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines keybindings. It satisfies the help.KeyMap interface.
+type KeyMap struct {
+	Back key.Binding
+}
+
+func init() {
+	keys.Define("executor", KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
+func DefaultKeyMap() KeyMap {
+	return keys.Apply("executor", KeyMap{
+		Back: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q", "back"),
+		),
+	})
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Back,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{
+		k.Back,
+	}}
+}
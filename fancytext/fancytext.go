@@ -2,7 +2,10 @@ package fancytext
 
 import (
 	"fmt"
+	"github.com/Funkit/theiere/keys"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -11,6 +14,8 @@ type Model struct {
 	Content   string
 	Style     lipgloss.Style
 	fixedSize bool
+	KeyMap    KeyMap
+	custom    bool
 }
 
 type options struct {
@@ -85,8 +90,8 @@ func New(opts ...Option) (Model, error) {
 	style := lipgloss.NewStyle().
 		Align(lipgloss.Center, lipgloss.Center).
 		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
-		Background(lipgloss.Color("#7D56F4"))
+		Foreground(theme.Current().Contrast).
+		Background(theme.Current().Primary)
 	if options.style != nil {
 		style = *options.style
 	}
@@ -98,6 +103,8 @@ func New(opts ...Option) (Model, error) {
 		Content:   options.content,
 		Style:     style,
 		fixedSize: options.fixedSize,
+		KeyMap:    DefaultKeyMap(),
+		custom:    options.style != nil,
 	}, nil
 }
 
@@ -115,8 +122,7 @@ func (m *Model) View() string {
 func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
 	switch val := msg.(type) {
 	case tea.KeyMsg:
-		switch val.String() {
-		case "esc", "q":
+		if key.Matches(val, m.KeyMap.Back) {
 			return m, subview.GoUp
 		}
 	}
@@ -138,3 +144,19 @@ func (m *Model) SetHeight(height int) {
 }
 
 func (m *Model) Reset() {}
+
+// DeclareKeys declares the binding going back.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.AddKeyMap(owner, keys.Component, m.KeyMap)
+}
+
+func (m *Model) KeyHelp() []keys.Group {
+	return []keys.Group{{Title: "Text", Bindings: m.KeyMap.ShortHelp()}}
+}
+
+// SetTheme applies a theme to the text colors, unless the style was set with WithStyle.
+func (m *Model) SetTheme(t theme.Theme) {
+	if !m.custom {
+		m.Style = m.Style.Copy().Foreground(t.Contrast).Background(t.Primary)
+	}
+}
@@ -1,17 +1,27 @@
 package frame
 
 import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/mouse"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 type Model struct {
-	borderColor lipgloss.AdaptiveColor
+	border      bool
+	borderColor *lipgloss.AdaptiveColor
 	Style       lipgloss.Style
 	Content     subview.Model
 	hasContent  bool
 	fixedSize   bool
+	KeyMap      KeyMap
+	Help        help.Model
+	helpBar     bool
+	helpOpen    bool
 }
 
 type options struct {
@@ -23,10 +33,21 @@ type options struct {
 	horizontalAlignment *lipgloss.Position
 	verticalAlignment   *lipgloss.Position
 	fixedSize           *bool
+	keyMap              *KeyMap
+	hideHelpBar         bool
 }
 
 type Option func(options *options) error
 
+// WithKeyMap replaces the default key bindings.
+func WithKeyMap(keyMap KeyMap) Option {
+	return func(options *options) error {
+		options.keyMap = &keyMap
+
+		return nil
+	}
+}
+
 func WithWidth(width int) Option {
 	return func(options *options) error {
 		options.width = &width
@@ -90,6 +111,15 @@ func WithFixedSize() Option {
 	}
 }
 
+// WithoutHelpBar hides the bar showing the active bindings below the content. The help overlay remains available.
+func WithoutHelpBar() Option {
+	return func(options *options) error {
+		options.hideHelpBar = true
+
+		return nil
+	}
+}
+
 func New(opts ...Option) (Model, error) {
 
 	var options options
@@ -130,7 +160,7 @@ func New(opts ...Option) (Model, error) {
 		Height(height).
 		AlignHorizontal(horizontalAlignment).AlignVertical(verticalAlignment)
 	if options.border {
-		color := lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"}
+		color := theme.Current().Border
 		if options.borderColor != nil {
 			color = *options.borderColor
 		}
@@ -138,16 +168,26 @@ func New(opts ...Option) (Model, error) {
 			BorderForeground(color)
 	}
 
+	keyMap := DefaultKeyMap()
+	if options.keyMap != nil {
+		keyMap = *options.keyMap
+	}
+
 	m := Model{
-		Style:     style,
-		fixedSize: fixedSize,
+		border:      options.border,
+		borderColor: options.borderColor,
+		Style:       style,
+		fixedSize:   fixedSize,
+		KeyMap:      keyMap,
+		Help:        help.New(),
+		helpBar:     !options.hideHelpBar,
 	}
 
 	if options.component != nil {
 		m.hasContent = true
 		m.Content = *options.component
 		m.Content.SetWidth(width - 2)
-		m.Content.SetHeight(height - 2)
+		m.Content.SetHeight(height - 2 - m.helpBarHeight())
 	}
 
 	return m, nil
@@ -161,10 +201,27 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) View() string {
+	if m.helpOpen {
+		return m.Style.Render(m.helpView())
+	}
+
+	content := ""
 	if m.hasContent {
-		return m.Style.Render(m.Content.View())
+		content = m.Content.View()
 	}
-	return m.Style.Render("")
+	if !m.helpBar {
+		return m.Style.Render(content)
+	}
+
+	body := lipgloss.NewStyle().
+		Width(m.Style.GetWidth()).
+		Height(m.Style.GetHeight() - 1).
+		MaxHeight(m.Style.GetHeight() - 1).
+		AlignHorizontal(m.Style.GetAlignHorizontal()).
+		AlignVertical(m.Style.GetAlignVertical()).
+		Render(content)
+
+	return m.Style.Render(lipgloss.JoinVertical(lipgloss.Left, body, m.helpBarView()))
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -174,21 +231,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Style.Width(recv.Width - 2)
 			m.Style.Height(recv.Height - 2)
 			if m.hasContent {
-				m.Content.SetHeight(recv.Height - 2)
+				m.Content.SetHeight(recv.Height - 2 - m.helpBarHeight())
 				m.Content.SetWidth(recv.Width - 2)
 			}
 		} else {
 			if m.hasContent {
-				m.Content.SetHeight(m.Style.GetHeight())
+				m.Content.SetHeight(m.Style.GetHeight() - m.helpBarHeight())
 				m.Content.SetWidth(m.Style.GetWidth())
 			}
 		}
 		return m, nil
+	case theme.Changed:
+		theme.Set(recv.Theme)
+		m.setTheme(theme.Current())
+		return m, nil
 	case tea.KeyMsg:
-		switch recv.String() {
-		case "ctrl+c":
-			return m, tea.Quit
+		if keys.Handles(keys.Global, recv, m.Content) {
+			switch {
+			case key.Matches(recv, m.KeyMap.Quit):
+				return m, tea.Quit
+			case key.Matches(recv, m.KeyMap.Help):
+				m.helpOpen = !m.helpOpen
+				return m, nil
+			}
 		}
+		if m.helpOpen {
+			if recv.Type == tea.KeyEsc {
+				m.helpOpen = false
+			}
+			return m, nil
+		}
+	case tea.MouseMsg:
+		if m.helpOpen || !m.hasContent {
+			return m, nil
+		}
+		x, y := m.contentOffset()
+		var cmd tea.Cmd
+		m.Content, cmd = m.Content.Update(mouse.Translate(recv, x, y))
+		return m, cmd
 	}
 
 	if m.hasContent {
@@ -200,3 +280,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	return m, nil
 }
+
+// contentOffset returns where the content is drawn: inside the border, aligned in the space above the help bar.
+func (m Model) contentOffset() (x, y int) {
+	if m.border {
+		x, y = 1, 1
+	}
+	width, height := mouse.Size(m.Content.View())
+	x += mouse.Offset(m.Style.GetWidth(), width, m.Style.GetAlignHorizontal())
+	y += mouse.Offset(m.Style.GetHeight()-m.helpBarHeight(), height, m.Style.GetAlignVertical())
+	return x, y
+}
+
+// setTheme applies a theme to the border, unless its color was set with WithBorderColor, and to the content.
+func (m *Model) setTheme(t theme.Theme) {
+	if m.border && m.borderColor == nil {
+		m.Style = m.Style.Copy().BorderForeground(t.Border)
+	}
+	if m.hasContent {
+		theme.Apply(m.Content, t)
+	}
+}
+
+// DeclareKeys declares the global bindings of the frame, then the bindings of its content.
+func (m Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.AddKeyMap(owner, keys.Global, m.KeyMap)
+	if m.hasContent {
+		registry.Declare(m.Content, owner)
+	}
+}
+
+// KeyHelp returns the global bindings of the frame, followed by the bindings of its content.
+func (m Model) KeyHelp() []keys.Group {
+	groups := []keys.Group{{Title: "Application", Bindings: []key.Binding{m.KeyMap.Help, m.KeyMap.Quit}}}
+	if m.hasContent {
+		groups = append(groups, keys.Help(m.Content)...)
+	}
+	return groups
+}
@@ -0,0 +1,80 @@
+package frame
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+var (
+	helpTitleStyle  = lipgloss.NewStyle().Bold(true).MarginBottom(1)
+	groupTitleStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+	groupStyle      = lipgloss.NewStyle().MarginRight(4).MarginBottom(1)
+)
+
+func (m Model) helpBarHeight() int {
+	if m.helpBar {
+		return 1
+	}
+	return 0
+}
+
+// helpBarView shows the active bindings on one line, the ones of the innermost component first.
+func (m Model) helpBarView() string {
+	groups := m.KeyHelp()
+	var bindings []key.Binding
+	for i := len(groups) - 1; i >= 0; i-- {
+		bindings = append(bindings, groups[i].Enabled()...)
+	}
+
+	m.Help.Width = m.Style.GetWidth()
+	return m.Help.ShortHelpView(bindings)
+}
+
+// helpView shows all the active bindings, grouped by component.
+func (m Model) helpView() string {
+	width := m.Style.GetWidth()
+	styles := m.Help.Styles
+
+	var rows, row []string
+	rowWidth := 0
+	for _, group := range m.KeyHelp() {
+		bindings := group.Enabled()
+		if len(bindings) == 0 {
+			continue
+		}
+
+		keyWidth := 0
+		for _, b := range bindings {
+			keyWidth = max(keyWidth, lipgloss.Width(b.Help().Key))
+		}
+		lines := []string{groupTitleStyle.Render(group.Title)}
+		for _, b := range bindings {
+			padding := strings.Repeat(" ", keyWidth-lipgloss.Width(b.Help().Key)+2)
+			lines = append(lines, styles.FullKey.Render(b.Help().Key)+padding+styles.FullDesc.Render(b.Help().Desc))
+		}
+		block := groupStyle.Render(strings.Join(lines, "\n"))
+
+		if rowWidth > 0 && rowWidth+lipgloss.Width(block) > width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		row = append(row, block)
+		rowWidth += lipgloss.Width(block)
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	closeHint := styles.ShortKey.Render(m.KeyMap.Help.Help().Key+"/esc") + " " + styles.ShortDesc.Render("close help")
+	content := lipgloss.JoinVertical(lipgloss.Left, append(append([]string{helpTitleStyle.Render("Key bindings")}, rows...), closeHint)...)
+
+	return lipgloss.NewStyle().Width(width).Height(m.Style.GetHeight()).MaxHeight(m.Style.GetHeight()).Render(content)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
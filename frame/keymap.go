@@ -0,0 +1,46 @@
+package frame
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines keybindings. It satisfies the help.KeyMap interface.
+type KeyMap struct {
+	// Quit exits the application from any screen.
+	Quit key.Binding
+	// Help shows the bindings of the displayed components, grouped by component.
+	Help key.Binding
+}
+
+func init() {
+	keys.Define("frame", KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
+func DefaultKeyMap() KeyMap {
+	return keys.Apply("frame", KeyMap{
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "quit"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "help"),
+		),
+	})
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Help,
+		k.Quit,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{
+		k.Help,
+		k.Quit,
+	}}
+}
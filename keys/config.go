@@ -0,0 +1,253 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/key"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Config overrides the key bindings of the components. Bindings are keyed by component and action name,
+// like "tree" and "next_match", and list the keys of the action. An empty list disables the action.
+// The bindings are applied over the preset, if any.
+//
+//	preset: vim
+//	bindings:
+//	  subtable:
+//	    export: [x]
+type Config struct {
+	Preset   string                         `json:"preset" yaml:"preset" toml:"preset"`
+	Bindings map[string]map[string][]string `json:"bindings" yaml:"bindings" toml:"bindings"`
+}
+
+var (
+	// actions holds the action names of the key maps defined by the components, by component.
+	actions = make(map[string]map[string]string)
+	// overrides holds the bindings of the configuration in use, by component and normalized action name.
+	overrides = make(map[string]map[string][]string)
+)
+
+// Define declares the key map of a component, so that configurations can be validated against its actions.
+// Every exported key.Binding field of the key map struct is an action.
+func Define(component string, keyMap any) {
+	names := make(map[string]string)
+	t := reflect.TypeOf(keyMap)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && field.Type == reflect.TypeOf(key.Binding{}) {
+			names[normalize(field.Name)] = field.Name
+		}
+	}
+	actions[component] = names
+}
+
+// Apply returns the key map with the bindings overridden by the configuration in use.
+// Components call it when building their default key map.
+func Apply[T any](component string, keyMap T) T {
+	actionKeys, ok := overrides[component]
+	if !ok {
+		return keyMap
+	}
+
+	v := reflect.ValueOf(&keyMap).Elem()
+	if v.Kind() != reflect.Struct {
+		return keyMap
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		binding, ok := v.Field(i).Interface().(key.Binding)
+		if !ok {
+			continue
+		}
+		keys, ok := actionKeys[normalize(field.Name)]
+		if !ok {
+			continue
+		}
+		v.Field(i).Set(reflect.ValueOf(override(binding, keys)))
+	}
+
+	return keyMap
+}
+
+// Use validates a configuration and applies it to the key maps built from now on.
+func Use(config Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	bindings := make(map[string]map[string][]string)
+	layers := []map[string]map[string][]string{config.Bindings}
+	if config.Preset != "" {
+		layers = []map[string]map[string][]string{presets[config.Preset], config.Bindings}
+	}
+	for _, layer := range layers {
+		for component, actionKeys := range layer {
+			if bindings[component] == nil {
+				bindings[component] = make(map[string][]string)
+			}
+			for action, keys := range actionKeys {
+				bindings[component][normalize(action)] = keys
+			}
+		}
+	}
+	overrides = bindings
+
+	return nil
+}
+
+// Load reads a configuration file and uses it. See LoadConfig.
+func Load(path string) error {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	return Use(config)
+}
+
+// LoadConfig reads a configuration file, in JSON, YAML or TOML depending on its extension.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &config)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &config)
+	case ".toml":
+		err = toml.Unmarshal(data, &config)
+	default:
+		return Config{}, fmt.Errorf("unsupported key binding file format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot read key bindings from %s: %w", path, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid key bindings in %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate checks that the preset exists, and that the components, actions and keys are known.
+func (c Config) Validate() error {
+	var problems []string
+	if _, ok := presets[c.Preset]; c.Preset != "" && !ok {
+		problems = append(problems, fmt.Sprintf("unknown preset %q, expected one of %s", c.Preset, strings.Join(Presets(), ", ")))
+	}
+
+	for component, actionKeys := range c.Bindings {
+		names, ok := actions[component]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown component %q", component))
+			continue
+		}
+		for action, keys := range actionKeys {
+			if _, ok := names[normalize(action)]; !ok {
+				problems = append(problems, fmt.Sprintf("unknown action %q for component %q", action, component))
+				continue
+			}
+			for _, k := range keys {
+				if !validKey(k) {
+					problems = append(problems, fmt.Sprintf("invalid key %q for %s.%s", k, component, action))
+				}
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%s", strings.Join(problems, "; "))
+}
+
+// Actions returns the action names of a component, as accepted in configurations.
+func Actions(component string) []string {
+	var names []string
+	for _, name := range actions[component] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func override(binding key.Binding, keys []string) key.Binding {
+	if len(keys) == 0 {
+		binding.SetEnabled(false)
+		return binding
+	}
+
+	var bound, help []string
+	for _, k := range keys {
+		if k == "space" {
+			k = " "
+		}
+		bound = append(bound, k)
+		help = append(help, helpKey(k))
+	}
+	return key.NewBinding(key.WithKeys(bound...), key.WithHelp(strings.Join(help, "/"), binding.Help().Desc))
+}
+
+func helpKey(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	}
+	return k
+}
+
+// normalize makes action names case insensitive, with or without separators: "NextMatch", "next_match", "next-match".
+func normalize(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// namedKeys holds the names of the non printable keys, as reported by tea.KeyMsg.String().
+var namedKeys = func() map[string]bool {
+	names := map[string]bool{"space": true}
+	for _, name := range []string{"up", "down", "left", "right", "home", "end", "pgup", "pgdown",
+		"enter", "esc", "tab", "backspace", "delete", "insert", "shift+tab"} {
+		names[name] = true
+	}
+	for _, name := range []string{"up", "down", "left", "right", "home", "end"} {
+		names["shift+"+name] = true
+		names["ctrl+shift+"+name] = true
+	}
+	for _, name := range []string{"up", "down", "left", "right", "home", "end", "pgup", "pgdown",
+		"@", "\\", "]", "^", "_"} {
+		names["ctrl+"+name] = true
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		names["ctrl+"+string(c)] = true
+	}
+	for i := 1; i <= 20; i++ {
+		names[fmt.Sprintf("f%d", i)] = true
+	}
+	return names
+}()
+
+func validKey(k string) bool {
+	k = strings.TrimPrefix(k, "alt+")
+	return utf8.RuneCountInString(k) == 1 || namedKeys[k]
+}
@@ -0,0 +1,235 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testKeyMap struct {
+	NextMatch key.Binding
+	Quit      key.Binding
+	Other     string
+	hidden    key.Binding
+}
+
+func init() {
+	Define("test", testKeyMap{})
+}
+
+func defaultTestKeyMap() testKeyMap {
+	return Apply("test", testKeyMap{
+		NextMatch: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+		Quit:      key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+	})
+}
+
+func useConfig(t *testing.T, config Config) {
+	t.Helper()
+	if err := Use(config); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { overrides = make(map[string]map[string][]string) })
+}
+
+func TestDefineListsBindingFields(t *testing.T) {
+	if got, want := Actions("test"), []string{"NextMatch", "Quit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %q, want %q", got, want)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := map[string]string{
+		"keys.json": `{"preset": "vim", "bindings": {"test": {"next_match": ["ctrl+n", "space"]}}}`,
+		"keys.yaml": "preset: vim\nbindings:\n  test:\n    next_match: [ctrl+n, space]\n",
+		"keys.yml":  "preset: vim\nbindings:\n  test:\n    next_match: [ctrl+n, space]\n",
+		"keys.toml": "preset = \"vim\"\n[bindings.test]\nnext_match = [\"ctrl+n\", \"space\"]\n",
+	}
+	want := Config{Preset: "vim", Bindings: map[string]map[string][]string{"test": {"next_match": {"ctrl+n", "space"}}}}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			config, err := LoadConfig(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(config, want) {
+				t.Errorf("config = %+v, want %+v", config, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		problem string
+	}{
+		{"unsupported format", "keys.ini", "", "unsupported key binding file format"},
+		{"syntax", "keys.json", `{"bindings": `, "cannot read key bindings"},
+		{"invalid bindings", "keys.json", `{"bindings": {"test": {"jump": ["j"]}}}`, `unknown action "jump"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.file)
+			if err := os.WriteFile(path, []byte(test.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(path)
+			if err == nil || !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("error = %v, want %q", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		problem string
+	}{
+		{"unknown preset", Config{Preset: "nano"}, `unknown preset "nano"`},
+		{"unknown component", Config{Bindings: map[string]map[string][]string{"editor": {"quit": {"q"}}}}, `unknown component "editor"`},
+		{"unknown action", Config{Bindings: map[string]map[string][]string{"test": {"Other": {"o"}}}}, `unknown action "Other"`},
+		{"unexported action", Config{Bindings: map[string]map[string][]string{"test": {"hidden": {"h"}}}}, `unknown action "hidden"`},
+		{"invalid key", Config{Bindings: map[string]map[string][]string{"test": {"quit": {"ctrl+alt+q"}}}}, `invalid key "ctrl+alt+q" for test.quit`},
+		{"word key", Config{Bindings: map[string]map[string][]string{"test": {"quit": {"quit"}}}}, `invalid key "quit"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Validate()
+			if err == nil || !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("error = %v, want %q", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestValidateAcceptsKeys(t *testing.T) {
+	config := Config{Preset: "emacs", Bindings: map[string]map[string][]string{
+		"test": {"next-match": {"alt+n", "f5", "ctrl+shift+up", "é", "space"}, "QUIT": {}},
+	}}
+	if err := config.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	useConfig(t, Config{Bindings: map[string]map[string][]string{
+		"test": {"next_match": {"right", "space"}, "quit": {}},
+	}})
+
+	km := defaultTestKeyMap()
+	if got, want := km.NextMatch.Keys(), []string{"right", " "}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keys = %q, want %q", got, want)
+	}
+	if help := km.NextMatch.Help(); help.Key != "→/space" || help.Desc != "next match" {
+		t.Errorf("help = %+v, want →/space and the default description", help)
+	}
+	if km.Quit.Enabled() {
+		t.Error("an empty key list did not disable the action")
+	}
+}
+
+func TestApplyBindingsOverPreset(t *testing.T) {
+	presets["test"] = map[string]map[string][]string{"test": {"next_match": {"N"}, "quit": {"Q"}}}
+	t.Cleanup(func() { delete(presets, "test") })
+	useConfig(t, Config{Preset: "test", Bindings: map[string]map[string][]string{
+		"test": {"Quit": {"x"}},
+	}})
+
+	km := defaultTestKeyMap()
+	if got, want := km.NextMatch.Keys(), []string{"N"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("next match = %q, want the preset keys %q", got, want)
+	}
+	if got, want := km.Quit.Keys(), []string{"x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("quit = %q, want %q", got, want)
+	}
+}
+
+func TestUseRejectsInvalidConfig(t *testing.T) {
+	useConfig(t, Config{Bindings: map[string]map[string][]string{"test": {"quit": {"x"}}}})
+	if err := Use(Config{Preset: "nano"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := defaultTestKeyMap().Quit.Keys(); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("keys = %q, the configuration in use was replaced", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	quit := key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q", "quit"))
+	back := key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back"))
+	search := key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search"))
+	filter := key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter"))
+	disabled := key.NewBinding(key.WithKeys("q"), key.WithDisabled())
+
+	tests := []struct {
+		name      string
+		declare   func(r *Registry)
+		conflicts []string
+	}{
+		{"distinct components", func(r *Registry) {
+			r.Add("main/tree", Component, search)
+			r.Add("main/list", Component, filter)
+			r.Add("main/list", Screen, back)
+		}, nil},
+		{"same component and scope", func(r *Registry) {
+			r.Add("main/list", Component, search, filter)
+		}, []string{"/"}},
+		{"global shadowing a component", func(r *Registry) {
+			r.Add("main", Global, quit)
+			r.Add("main/list", Screen, back)
+		}, []string{"esc"}},
+		{"global of the same owner in another scope", func(r *Registry) {
+			r.Add("main", Global, quit)
+			r.Add("main", Component, back)
+		}, nil},
+		{"disabled binding", func(r *Registry) {
+			r.Add("main", Global, quit)
+			r.Add("main/list", Component, disabled)
+		}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRegistry()
+			test.declare(r)
+
+			var conflicts []string
+			for _, c := range r.Conflicts() {
+				conflicts = append(conflicts, c.Key)
+			}
+			if !reflect.DeepEqual(conflicts, test.conflicts) {
+				t.Errorf("conflicts = %q, want %q", conflicts, test.conflicts)
+			}
+			if err := r.Check(); (err != nil) != (test.conflicts != nil) {
+				t.Errorf("Check() = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckDescribesConflicts(t *testing.T) {
+	r := NewRegistry()
+	r.Add("main", Global, key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")))
+	r.Add("main/form", Component, key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "query")))
+
+	err := r.Check()
+	want := "conflicting key bindings:\nkey \"q\" is bound by main \"quit\" (global) and main/form \"query\" (component)"
+	if err == nil || err.Error() != want {
+		t.Errorf("Check() = %v, want %q", err, want)
+	}
+}
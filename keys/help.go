@@ -0,0 +1,35 @@
+package keys
+
+import "github.com/charmbracelet/bubbles/key"
+
+// Group is a set of bindings shown together in the help, under the name of the component declaring them.
+type Group struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// Helper is implemented by the components exposing the bindings active right now.
+// Containers return their own groups followed by the groups of their displayed content,
+// so that the root component returns the bindings of the whole focused component chain.
+type Helper interface {
+	KeyHelp() []Group
+}
+
+// Help returns the active bindings of a component, if it is a Helper.
+func Help(component any) []Group {
+	if helper, ok := component.(Helper); ok {
+		return helper.KeyHelp()
+	}
+	return nil
+}
+
+// Enabled returns the enabled bindings of a group.
+func (g Group) Enabled() []key.Binding {
+	var bindings []key.Binding
+	for _, b := range g.Bindings {
+		if b.Enabled() {
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings
+}
@@ -0,0 +1,179 @@
+package keys
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"sort"
+	"strings"
+)
+
+// Scope tells where a binding applies. Keys are matched from the outermost scope to the innermost one:
+// global bindings first, then the ones of the displayed screen, then the ones of the component.
+// The only exception is a component capturing text input, which receives text keys before any other scope.
+type Scope int
+
+const (
+	// Global bindings apply on every screen, like quitting or opening the command palette.
+	Global Scope = iota
+	// Screen bindings apply to the navigation of a screen, like going back in a menu.
+	Screen
+	// Component bindings apply to the component displayed, like moving in a table.
+	Component
+)
+
+func (s Scope) String() string {
+	switch s {
+	case Global:
+		return "global"
+	case Screen:
+		return "screen"
+	default:
+		return "component"
+	}
+}
+
+// Capturer is implemented by the components with a text field, like a filter or a search prompt.
+// Containers implement it as well, reporting whether their displayed content is capturing.
+type Capturer interface {
+	CapturingInput() bool
+}
+
+// Declarer is implemented by the components declaring their bindings in a registry.
+// Containers declare the bindings of their content too, under a longer owner path.
+type Declarer interface {
+	DeclareKeys(registry *Registry, owner string)
+}
+
+// Binding is a binding declared in a registry.
+type Binding struct {
+	// Owner is the path of the component declaring the binding, like "main/settings".
+	Owner string
+	Scope Scope
+	key.Binding
+}
+
+// Conflict lists bindings sharing a key that cannot all be reached.
+type Conflict struct {
+	Key      string
+	Bindings []Binding
+}
+
+func (c Conflict) String() string {
+	var descriptions []string
+	for _, b := range c.Bindings {
+		descriptions = append(descriptions, fmt.Sprintf("%s %q (%s)", b.Owner, b.Help().Desc, b.Scope))
+	}
+	return fmt.Sprintf("key %q is bound by %s", c.Key, strings.Join(descriptions, " and "))
+}
+
+// Registry holds the bindings declared by the components of an application, to detect conflicts at startup.
+type Registry struct {
+	bindings []Binding
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Add declares bindings. Disabled bindings are ignored.
+func (r *Registry) Add(owner string, scope Scope, bindings ...key.Binding) {
+	for _, b := range bindings {
+		if b.Enabled() && len(b.Keys()) > 0 {
+			r.bindings = append(r.bindings, Binding{Owner: owner, Scope: scope, Binding: b})
+		}
+	}
+}
+
+// AddKeyMap declares the bindings of a key map, as listed in its full help.
+func (r *Registry) AddKeyMap(owner string, scope Scope, keyMap help.KeyMap) {
+	for _, column := range keyMap.FullHelp() {
+		r.Add(owner, scope, column...)
+	}
+}
+
+// Declare declares the bindings of a component, if it is a Declarer.
+func (r *Registry) Declare(component any, owner string) {
+	if declarer, ok := component.(Declarer); ok {
+		declarer.DeclareKeys(r, owner)
+	}
+}
+
+// Bindings returns the declared bindings.
+func (r *Registry) Bindings() []Binding {
+	return r.bindings
+}
+
+// Conflicts returns the keys bound twice where only one binding can be reached:
+// twice in the same scope of the same component, or by a global binding shadowing the binding of another component.
+func (r *Registry) Conflicts() []Conflict {
+	var conflicts []Conflict
+	for i, a := range r.bindings {
+		for _, b := range r.bindings[i+1:] {
+			sameComponent := a.Owner == b.Owner && a.Scope == b.Scope
+			shadowed := (a.Scope == Global || b.Scope == Global) && a.Owner != b.Owner
+			if !sameComponent && !shadowed {
+				continue
+			}
+			for _, k := range shared(a.Keys(), b.Keys()) {
+				conflicts = append(conflicts, Conflict{Key: k, Bindings: []Binding{a, b}})
+			}
+		}
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		return conflicts[i].Key < conflicts[j].Key
+	})
+	return conflicts
+}
+
+// Check returns an error describing the conflicts, if any.
+func (r *Registry) Check() error {
+	conflicts := r.Conflicts()
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	var lines []string
+	for _, c := range conflicts {
+		lines = append(lines, c.String())
+	}
+	return fmt.Errorf("conflicting key bindings:\n%s", strings.Join(lines, "\n"))
+}
+
+// Handles tells whether a binding of the given scope may handle a key, before passing it to the target component.
+// Text keys go to the target when it is capturing text input, so that a text field can get "q" without navigating away.
+func Handles(scope Scope, msg tea.KeyMsg, target any) bool {
+	if scope == Component || !IsText(msg) {
+		return true
+	}
+	return !Capturing(target)
+}
+
+// Capturing tells whether the component is capturing text input.
+func Capturing(component any) bool {
+	capturer, ok := component.(Capturer)
+	return ok && capturer.CapturingInput()
+}
+
+// IsText tells whether the key edits text: printable characters, space and backspace.
+func IsText(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes, tea.KeySpace, tea.KeyBackspace:
+		return !msg.Alt
+	}
+	return false
+}
+
+func shared(a, b []string) []string {
+	var keys []string
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				keys = append(keys, x)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,89 @@
+package keys
+
+import "sort"
+
+// presets holds the built-in configurations, by name. The default preset keeps the compiled-in bindings.
+var presets = map[string]map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		"subtable": {
+			"line_up":        {"up", "k"},
+			"line_down":      {"down", "j"},
+			"page_up":        {"pgup", "ctrl+b"},
+			"page_down":      {"pgdown", "ctrl+f"},
+			"half_page_up":   {"ctrl+u"},
+			"half_page_down": {"ctrl+d"},
+			"goto_top":       {"home", "g"},
+			"goto_bottom":    {"end", "G"},
+			"scroll_left":    {"left", "h"},
+			"scroll_right":   {"right", "l"},
+		},
+		"tabs": {
+			"next_page": {"tab", "L"},
+			"prev_page": {"shift+tab", "H"},
+		},
+		"palette": {
+			"open": {"ctrl+p", ":"},
+		},
+	},
+	"emacs": {
+		"menu.list": {
+			"cursor_up":   {"up", "ctrl+p"},
+			"cursor_down": {"down", "ctrl+n"},
+			"next_page":   {"right", "pgdown", "ctrl+v"},
+			"prev_page":   {"left", "pgup", "alt+v"},
+			"go_to_start": {"home", "alt+<"},
+			"go_to_end":   {"end", "alt+>"},
+			"filter":      {"/", "ctrl+s"},
+		},
+		"menu": {
+			"back": {"q", "esc", "ctrl+g"},
+		},
+		"subtable": {
+			"line_up":      {"up", "ctrl+p"},
+			"line_down":    {"down", "ctrl+n"},
+			"page_up":      {"pgup", "alt+v"},
+			"page_down":    {"pgdown", "ctrl+v"},
+			"goto_top":     {"home", "alt+<"},
+			"goto_bottom":  {"end", "alt+>"},
+			"scroll_left":  {"left", "ctrl+b"},
+			"scroll_right": {"right", "ctrl+f"},
+			"quit":         {"q", "esc", "ctrl+g"},
+		},
+		"tree": {
+			"up":         {"up", "ctrl+p"},
+			"down":       {"down", "ctrl+n"},
+			"expand":     {"right", "ctrl+f"},
+			"collapse":   {"left", "ctrl+b"},
+			"search":     {"/", "ctrl+s"},
+			"next_match": {"n", "alt+n"},
+			"prev_match": {"N", "alt+p"},
+			"quit":       {"q", "esc", "ctrl+g"},
+		},
+		"palette": {
+			"open":  {"alt+x"},
+			"up":    {"up", "ctrl+p"},
+			"down":  {"down", "ctrl+n"},
+			"close": {"esc", "ctrl+g", "alt+x"},
+		},
+	},
+}
+
+// Presets returns the names of the built-in configurations.
+func Presets() []string {
+	var names []string
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns a built-in configuration by name: default, vim or emacs.
+func Preset(name string) (Config, error) {
+	config := Config{Preset: name}
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
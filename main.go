@@ -1,46 +1,127 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/Funkit/theiere/fancytext"
 	"github.com/Funkit/theiere/frame"
+	"github.com/Funkit/theiere/keys"
 	"github.com/Funkit/theiere/menu"
+	"github.com/Funkit/theiere/palette"
+	"github.com/Funkit/theiere/split"
 	"github.com/Funkit/theiere/subframe"
 	"github.com/Funkit/theiere/tabs"
+	"github.com/Funkit/theiere/theme"
+	"github.com/Funkit/theiere/theme/preview"
 	"github.com/Funkit/theiere/validation"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"log"
 	"os"
+	"strings"
 )
 
 func main() {
+	if dir, err := theme.Dir(); err == nil {
+		// The valid themes are loaded even when some files are not.
+		if _, err := theme.LoadDir(dir); err != nil {
+			log.Print(err)
+		}
+	}
+
+	preset := flag.String("keys-preset", "default", "key binding preset: "+strings.Join(keys.Presets(), ", "))
+	keyFile := flag.String("keys", "", "key binding file (JSON, YAML or TOML), applied over the preset")
+	themeName := flag.String("theme", "default", "color theme: "+strings.Join(theme.Names(), ", "))
+	accessible := flag.Bool("accessible", false, "show states with text markers, without animations, in high contrast unless -theme is set")
+	flag.Parse()
+
+	access := theme.AccessFromEnv()
+	if *accessible {
+		access.Markers, access.ReducedMotion = true, true
+		themeSet := false
+		flag.Visit(func(f *flag.Flag) { themeSet = themeSet || f.Name == "theme" })
+		if !themeSet {
+			*themeName = theme.HighContrast.Name
+		}
+	}
+	theme.SetAccess(access)
+
+	t, ok := theme.Lookup(*themeName)
+	if !ok {
+		panic(fmt.Sprintf("unknown theme %q", *themeName))
+	}
+	theme.Set(t)
+
+	keyConfig := keys.Config{}
+	if *keyFile != "" {
+		var err error
+		keyConfig, err = keys.LoadConfig(*keyFile)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if keyConfig.Preset == "" {
+		keyConfig.Preset = *preset
+	}
+	if err := keys.Use(keyConfig); err != nil {
+		panic(err)
+	}
+
 	comm := make(chan struct{})
 	items := []menu.ListItem{
 		generateItem1(),
 		generateItem2(comm),
 		generateItem3(),
+		generateItem4(),
+	}
+
+	themes, err := preview.New()
+	if err != nil {
+		panic(err)
 	}
+	items = append(items, menu.ListItem{
+		Item:      menu.NewItem("themes", "Preview and switch the color theme"),
+		Component: &themes,
+	})
 
 	l, err := menu.New("This is a menu title", items)
 	if err != nil {
 		panic(err)
 	}
 
-	f, err := frame.New(frame.WithComponent(&l), frame.WithHorizontalAlignment(lipgloss.Left), frame.WithBorder())
+	quit := palette.Command{
+		Title:    "Quit",
+		Category: "Application",
+		Key:      key.NewBinding(key.WithKeys("ctrl+q"), key.WithHelp("ctrl+q", "quit")),
+		Run:      tea.Quit,
+	}
+	p, err := palette.New(&l, palette.WithCommands(append([]palette.Command{quit}, themes.Commands()...)...))
+	if err != nil {
+		panic(err)
+	}
+
+	f, err := frame.New(frame.WithComponent(&p), frame.WithHorizontalAlignment(lipgloss.Left), frame.WithBorder())
 	if err != nil {
 		panic(err)
 	}
 
-	p := tea.NewProgram(f, tea.WithAltScreen())
+	registry := keys.NewRegistry()
+	f.DeclareKeys(registry, "app")
+	if err := registry.Check(); err != nil {
+		panic(err)
+	}
+
+	program := tea.NewProgram(f, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 	go func(ch <-chan struct{}) {
 		select {
 		case <-comm:
-			p.Send(tea.Quit())
+			program.Send(tea.Quit())
 		}
 	}(comm)
 
-	if _, err := p.Run(); err != nil {
+	if _, err := program.Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
@@ -89,6 +170,8 @@ func generateItem3() menu.ListItem {
 	if err != nil {
 		panic(err)
 	}
+	tab2.Status = tabs.Status{Badge: 3}
+	tab3.Status = tabs.Status{Error: true}
 
 	contentTabs := []tabs.Tab{
 		tab1,
@@ -110,3 +193,24 @@ func generateItem3() menu.ListItem {
 		Component: &subf,
 	}
 }
+
+func generateItem4() menu.ListItem {
+	left, err := fancytext.New(fancytext.WithContent("LEFT"))
+	if err != nil {
+		panic(err)
+	}
+	right, err := fancytext.New(fancytext.WithContent("RIGHT"))
+	if err != nil {
+		panic(err)
+	}
+
+	panes, err := split.New(&left, &right, split.WithRatio(0.3))
+	if err != nil {
+		panic(err)
+	}
+
+	return menu.ListItem{
+		Item:      menu.NewItem("split", "two panes split by a divider to drag with the mouse"),
+		Component: &panes,
+	}
+}
@@ -0,0 +1,96 @@
+package menu
+
+import (
+	"github.com/Funkit/theiere/palette"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+// NavigateMsg opens the screen of an item from the menu receiving it, going through
+// the nested menus along the path of item IDs.
+type NavigateMsg struct {
+	Path []string
+}
+
+func (NavigateMsg) Upward() {}
+
+// Navigate returns the command opening the screen at the given path of item IDs.
+func Navigate(path ...string) tea.Cmd {
+	return func() tea.Msg {
+		return NavigateMsg{Path: path}
+	}
+}
+
+// Commands returns a palette command navigating to each enabled item of the menu and of its nested menus,
+// categorized by their breadcrumb trail. The commands of the subviews built so far follow their item, and navigate to
+// it before running.
+func (m *Model) Commands() []palette.Command {
+	return m.commands(nil, m.trail)
+}
+
+func (m *Model) commands(path, trail []string) []palette.Command {
+	var commands []palette.Command
+	for _, item := range m.Items() {
+		if item.disabled {
+			continue
+		}
+		itemPath := append(append([]string{}, path...), item.id)
+		commands = append(commands, palette.Command{
+			ID:       "menu/" + strings.Join(itemPath, "/"),
+			Title:    item.title,
+			Category: strings.Join(trail, breadcrumbSeparator),
+			Run:      Navigate(itemPath...),
+		})
+		itemTrail := append(append([]string{}, trail...), item.title)
+		switch sub := m.SubViews[item.id].(type) {
+		case *Model:
+			commands = append(commands, sub.commands(itemPath, itemTrail)...)
+		case palette.Commander:
+			for _, command := range sub.Commands() {
+				category := strings.Join(itemTrail, breadcrumbSeparator)
+				if command.Category != "" {
+					category += breadcrumbSeparator + command.Category
+				}
+				command.ID = "menu/" + strings.Join(itemPath, "/") + "/" + command.ID
+				command.Category = category
+				command.Run = tea.Sequence(Navigate(itemPath...), command.Run)
+				commands = append(commands, command)
+			}
+		}
+	}
+	return commands
+}
+
+// navigate closes the displayed screens and opens the one at the given path.
+func (m *Model) navigate(path []string) tea.Cmd {
+	m.closeAll()
+	if len(path) == 0 {
+		return nil
+	}
+
+	index, err := m.itemIndex(path[0])
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	item := m.list.Items()[index].(Item)
+	if item.disabled {
+		return nil
+	}
+	m.list.ResetFilter()
+	m.list.Select(index)
+
+	cmd := m.open(item)
+	if sub, ok := m.SubViews[item.id].(*Model); ok && m.choice == item.id {
+		return tea.Batch(cmd, sub.navigate(path[1:]))
+	}
+	return cmd
+}
+
+// closeAll goes back to this menu from any depth of nested menus.
+func (m *Model) closeAll() {
+	if sub, ok := m.SubViews[m.choice].(*Model); ok && m.choice != "" {
+		sub.closeAll()
+	}
+	m.close()
+}
@@ -1,17 +1,210 @@
 package menu
 
+import (
+	"fmt"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"io"
+	"strings"
+)
+
 type Item struct {
-	title string
-	desc  string
+	id       string
+	title    string
+	desc     string
+	disabled bool
+	badge    string
+	shortcut *key.Binding
+	icon     string
+	keywords []string
+}
+
+// ItemOption sets the metadata of a menu item.
+type ItemOption func(item *Item)
+
+// WithID sets the identifier of the item, which must be unique in the menu. The title is used by default.
+func WithID(id string) ItemOption {
+	return func(item *Item) {
+		item.id = id
+	}
+}
+
+// WithDisabled displays the item dimmed and prevents selecting it.
+func WithDisabled() ItemOption {
+	return func(item *Item) {
+		item.disabled = true
+	}
 }
 
-func NewItem(title, desc string) Item {
-	return Item{
+// WithBadge displays a short text next to the title, like a counter.
+func WithBadge(badge string) ItemOption {
+	return func(item *Item) {
+		item.badge = badge
+	}
+}
+
+// WithShortcut sets keys selecting the item directly from the menu.
+func WithShortcut(keys ...string) ItemOption {
+	return func(item *Item) {
+		shortcut := key.NewBinding(key.WithKeys(keys...), key.WithHelp(strings.Join(keys, "/"), ""))
+		item.shortcut = &shortcut
+	}
+}
+
+// shortcutBinding returns the shortcut of the item, described by its current title.
+func (i Item) shortcutBinding() key.Binding {
+	shortcut := *i.shortcut
+	shortcut.SetHelp(shortcut.Help().Key, i.title)
+	return shortcut
+}
+
+// WithIcon displays an icon before the title.
+func WithIcon(icon string) ItemOption {
+	return func(item *Item) {
+		item.icon = icon
+	}
+}
+
+// WithKeywords sets extra words matched when filtering the menu.
+func WithKeywords(keywords ...string) ItemOption {
+	return func(item *Item) {
+		item.keywords = keywords
+	}
+}
+
+func NewItem(title, desc string, opts ...ItemOption) Item {
+	item := Item{
+		id:    title,
 		title: title,
 		desc:  desc,
 	}
+	for _, opt := range opts {
+		opt(&item)
+	}
+
+	return item
 }
 
+func (i Item) ID() string          { return i.id }
 func (i Item) Title() string       { return i.title }
 func (i Item) Description() string { return i.desc }
-func (i Item) FilterValue() string { return i.title }
+func (i Item) Disabled() bool      { return i.disabled }
+func (i Item) Badge() string       { return i.badge }
+func (i Item) Icon() string        { return i.icon }
+
+// FilterValue is matched when filtering the menu: the title, the description and the keywords.
+func (i Item) FilterValue() string {
+	return strings.Join(append([]string{i.title, i.desc}, i.keywords...), " ")
+}
+
+// delegate renders the menu items with their icon, badge and shortcut, disabled items being dimmed.
+// When filtering, the matches are highlighted in the title and the description.
+type delegate struct {
+	list.DefaultDelegate
+}
+
+func newDelegate(t theme.Theme) delegate {
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = d.Styles.SelectedTitle.Copy().Foreground(t.Cursor).BorderForeground(t.CursorDesc)
+	d.Styles.SelectedDesc = d.Styles.SelectedDesc.Copy().Foreground(t.CursorDesc).BorderForeground(t.CursorDesc)
+	d.Styles.DimmedTitle = d.Styles.DimmedTitle.Copy().Foreground(t.Dimmed)
+	d.Styles.DimmedDesc = d.Styles.DimmedDesc.Copy().Foreground(t.DimmedDesc)
+
+	return delegate{DefaultDelegate: d}
+}
+
+func (d delegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(Item)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, listItem)
+		return
+	}
+	if m.Width() <= 0 {
+		return
+	}
+
+	s := d.Styles
+	var (
+		isSelected  = index == m.Index()
+		emptyFilter = m.FilterState() == list.Filtering && m.FilterValue() == ""
+		isFiltered  = m.FilterState() == list.Filtering || m.FilterState() == list.FilterApplied
+	)
+
+	titleStyle, descStyle := s.NormalTitle, s.NormalDesc
+	switch {
+	case emptyFilter:
+		titleStyle, descStyle = s.DimmedTitle, s.DimmedDesc
+	case isSelected && m.FilterState() != list.Filtering:
+		titleStyle, descStyle = s.SelectedTitle, s.SelectedDesc
+		if i.disabled {
+			titleStyle = titleStyle.Copy().Foreground(s.DimmedTitle.GetForeground())
+			descStyle = descStyle.Copy().Foreground(s.DimmedDesc.GetForeground())
+		}
+	case i.disabled:
+		titleStyle, descStyle = s.DimmedTitle, s.DimmedDesc
+	}
+
+	textWidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
+
+	prefix, suffix := "", ""
+	if i.icon != "" {
+		prefix = i.icon + " "
+	}
+	if i.badge != "" {
+		suffix = " [" + i.badge + "]"
+	}
+	title := runewidth.Truncate(i.title, max(0, textWidth-runewidth.StringWidth(prefix+suffix)), ellipsis)
+
+	desc := i.desc
+	if i.shortcut != nil {
+		desc += " · " + i.shortcut.Help().Key
+	}
+	desc = runewidth.Truncate(desc, textWidth, ellipsis)
+
+	if isFiltered && !emptyFilter {
+		titleMatches, descMatches := splitMatches(m.MatchesForItem(index), len([]rune(i.title)))
+		title = highlightRunes(title, titleMatches, titleStyle, s.FilterMatch)
+		desc = highlightRunes(desc, descMatches, descStyle, s.FilterMatch)
+	}
+
+	if d.ShowDescription {
+		fmt.Fprintf(w, "%s\n%s", titleStyle.Render(prefix+title+suffix), descStyle.Render(desc))
+		return
+	}
+	fmt.Fprintf(w, "%s", titleStyle.Render(prefix+title+suffix))
+}
+
+const ellipsis = "…"
+
+// splitMatches splits the indexes of the runes matched in the filter value between
+// the title and the description, the description indexes being made relative to it.
+func splitMatches(matches []int, titleLength int) (title, desc []int) {
+	for _, match := range matches {
+		switch {
+		case match < titleLength:
+			title = append(title, match)
+		case match > titleLength:
+			desc = append(desc, match-titleLength-1)
+		}
+	}
+	return title, desc
+}
+
+func highlightRunes(text string, matches []int, style, matchStyle lipgloss.Style) string {
+	if len(matches) == 0 {
+		return text
+	}
+	unmatched := style.Copy().Inline(true)
+	matched := unmatched.Copy().Inherit(matchStyle)
+	return lipgloss.StyleRunes(text, matches, matched, unmatched)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,262 @@
+package menu
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AddItemMsg adds an item at the end of a menu, or at Index if it is set.
+// Menu is the ID of the targeted menu, the menu receiving the message when empty.
+type AddItemMsg struct {
+	Menu  string
+	Item  ListItem
+	Index *int
+}
+
+func (AddItemMsg) Upward() {}
+
+// RemoveItemMsg removes an item from a menu.
+type RemoveItemMsg struct {
+	Menu string
+	ID   string
+}
+
+func (RemoveItemMsg) Upward() {}
+
+// MoveItemMsg moves an item of a menu to a new position.
+type MoveItemMsg struct {
+	Menu  string
+	ID    string
+	Index int
+}
+
+func (MoveItemMsg) Upward() {}
+
+// EnableItemMsg allows selecting a disabled item of a menu again.
+type EnableItemMsg struct {
+	Menu string
+	ID   string
+}
+
+func (EnableItemMsg) Upward() {}
+
+// DisableItemMsg dims an item of a menu and prevents selecting it.
+type DisableItemMsg struct {
+	Menu string
+	ID   string
+}
+
+func (DisableItemMsg) Upward() {}
+
+// UpdateItemMsg replaces the metadata of the item with the same ID, keeping its subview.
+// Errors applying item messages are displayed below the menu.
+type UpdateItemMsg struct {
+	Menu string
+	Item Item
+}
+
+func (UpdateItemMsg) Upward() {}
+
+// AddItem adds an item at the end of the menu. The returned command initializes its subview.
+func (m *Model) AddItem(item ListItem) (tea.Cmd, error) {
+	return m.InsertItem(len(m.list.Items()), item)
+}
+
+// InsertItem adds an item at the given position. The returned command initializes its subview,
+// unless it is built lazily.
+func (m *Model) InsertItem(index int, item ListItem) (tea.Cmd, error) {
+	if item.Item.id == "" {
+		return nil, fmt.Errorf("missing id for menu item %q", item.Item.title)
+	}
+	if _, exists := m.SubViews[item.Item.id]; exists {
+		return nil, fmt.Errorf("duplicate menu item id %q", item.Item.id)
+	}
+	if index < 0 || index > len(m.list.Items()) {
+		return nil, fmt.Errorf("invalid index %d for menu item %q", index, item.Item.id)
+	}
+
+	if err := m.registerItem(item); err != nil {
+		return nil, err
+	}
+
+	m.list.InsertItem(index, item.Item)
+	m.syncOrder()
+
+	if item.Component == nil {
+		return nil, nil
+	}
+	m.prepare(item.Component, item.Item.title)
+
+	return item.Component.Init(), nil
+}
+
+// RemoveItem removes an item and its subview. If the subview was displayed, the menu is displayed back.
+func (m *Model) RemoveItem(id string) error {
+	index, err := m.itemIndex(id)
+	if err != nil {
+		return err
+	}
+
+	if m.choice == id {
+		m.choice = ""
+	}
+	delete(m.SubViews, id)
+	delete(m.lazy, id)
+	m.list.RemoveItem(index)
+	m.syncOrder()
+
+	return nil
+}
+
+// MoveItem moves an item to a new position in the menu. The selection stays on the selected item.
+func (m *Model) MoveItem(id string, index int) error {
+	from, err := m.itemIndex(id)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(m.list.Items()) {
+		return fmt.Errorf("invalid index %d for menu item %q", index, id)
+	}
+
+	selected := m.list.Index()
+	item := m.list.Items()[from]
+	m.list.RemoveItem(from)
+	m.list.InsertItem(index, item)
+	switch {
+	case selected == from:
+		selected = index
+	case from < selected && index >= selected:
+		selected--
+	case from > selected && index <= selected:
+		selected++
+	}
+	m.list.Select(selected)
+	m.syncOrder()
+
+	return nil
+}
+
+// EnableItem allows selecting an item again.
+func (m *Model) EnableItem(id string) error {
+	return m.setDisabled(id, false)
+}
+
+// DisableItem dims an item and prevents selecting it.
+func (m *Model) DisableItem(id string) error {
+	return m.setDisabled(id, true)
+}
+
+// UpdateItem replaces the title, description and metadata of the item with the same ID.
+func (m *Model) UpdateItem(item Item) error {
+	index, err := m.itemIndex(item.id)
+	if err != nil {
+		return err
+	}
+
+	m.list.SetItem(index, item)
+	if sub, ok := m.SubViews[item.id].(*Model); ok {
+		sub.setTrail(append(append([]string{}, m.trail...), item.title))
+	}
+
+	return nil
+}
+
+// Items returns the items of the menu, in display order.
+func (m *Model) Items() []Item {
+	var items []Item
+	for _, listItem := range m.list.Items() {
+		if i, ok := listItem.(Item); ok {
+			items = append(items, i)
+		}
+	}
+	return items
+}
+
+func (m *Model) setDisabled(id string, disabled bool) error {
+	index, err := m.itemIndex(id)
+	if err != nil {
+		return err
+	}
+
+	item := m.list.Items()[index].(Item)
+	item.disabled = disabled
+	m.list.SetItem(index, item)
+
+	return nil
+}
+
+func (m *Model) itemIndex(id string) (int, error) {
+	for i, listItem := range m.list.Items() {
+		if item, ok := listItem.(Item); ok && item.id == id {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown menu item id %q", id)
+}
+
+// syncOrder keeps the subview order consistent with the list.
+func (m *Model) syncOrder() {
+	m.order = m.order[:0]
+	for _, item := range m.Items() {
+		m.order = append(m.order, item.id)
+	}
+}
+
+// updateItems applies an item message if it targets this menu or one of its nested menus.
+// It returns false if the message targets none of them.
+func (m *Model) updateItems(msg tea.Msg) (bool, tea.Cmd) {
+	var target string
+	switch msg := msg.(type) {
+	case AddItemMsg:
+		target = msg.Menu
+	case RemoveItemMsg:
+		target = msg.Menu
+	case MoveItemMsg:
+		target = msg.Menu
+	case EnableItemMsg:
+		target = msg.Menu
+	case DisableItemMsg:
+		target = msg.Menu
+	case UpdateItemMsg:
+		target = msg.Menu
+	default:
+		return false, nil
+	}
+
+	if target != "" && target != m.id {
+		for _, id := range m.order {
+			if sub, ok := m.SubViews[id].(*Model); ok {
+				if handled, cmd := sub.updateItems(msg); handled {
+					return true, cmd
+				}
+			}
+		}
+		return false, nil
+	}
+
+	var cmd tea.Cmd
+	var err error
+	switch msg := msg.(type) {
+	case AddItemMsg:
+		if msg.Index != nil {
+			cmd, err = m.InsertItem(*msg.Index, msg.Item)
+		} else {
+			cmd, err = m.AddItem(msg.Item)
+		}
+	case RemoveItemMsg:
+		err = m.RemoveItem(msg.ID)
+	case MoveItemMsg:
+		err = m.MoveItem(msg.ID, msg.Index)
+	case EnableItemMsg:
+		err = m.EnableItem(msg.ID)
+	case DisableItemMsg:
+		err = m.DisableItem(msg.ID)
+	case UpdateItemMsg:
+		err = m.UpdateItem(msg.Item)
+	}
+
+	if err != nil {
+		m.err = err
+	}
+	return true, cmd
+}
@@ -0,0 +1,56 @@
+package menu
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// KeyMap defines keybindings. It satisfies the help.KeyMap interface.
+type KeyMap struct {
+	Select key.Binding
+
+	// Back goes up one level in nested menus, and quits from the root menu.
+	Back key.Binding
+	// Root goes back to the root menu from nested menus.
+	Root key.Binding
+}
+
+func init() {
+	keys.Define("menu", KeyMap{})
+	keys.Define("menu.list", list.KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
+func DefaultKeyMap() KeyMap {
+	return keys.Apply("menu", KeyMap{
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Root: key.NewBinding(
+			key.WithKeys("~"),
+			key.WithHelp("~", "root menu"),
+		),
+	})
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Select,
+		k.Back,
+		k.Root,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{
+		k.Select,
+		k.Back,
+		k.Root,
+	}}
+}
@@ -0,0 +1,56 @@
+package menu
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// DeclareKeys declares the bindings of the menu and of its list as screen bindings, then the bindings of the
+// subviews under the item IDs. Subviews built lazily are declared once built.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.Add(owner, keys.Screen, m.KeyMap.Select, m.KeyMap.Back)
+	if m.isNested() {
+		registry.Add(owner, keys.Screen, m.KeyMap.Root)
+	}
+	registry.AddKeyMap(owner, keys.Screen, m.list)
+	for _, item := range m.Items() {
+		if item.shortcut != nil {
+			registry.Add(owner, keys.Screen, item.shortcutBinding())
+		}
+	}
+
+	for _, id := range m.order {
+		if m.SubViews[id] != nil {
+			registry.Declare(m.SubViews[id], owner+"/"+id)
+		}
+	}
+}
+
+// CapturingInput tells whether the filter is being typed, or the displayed subview is capturing text input.
+func (m *Model) CapturingInput() bool {
+	if m.choice != "" {
+		return keys.Capturing(m.SubViews[m.choice])
+	}
+	return m.list.FilterState() == list.Filtering
+}
+
+// KeyHelp returns the bindings of the menu list, or those of the displayed subview.
+func (m *Model) KeyHelp() []keys.Group {
+	if m.choice != "" {
+		return keys.Help(m.SubViews[m.choice])
+	}
+
+	bindings := m.list.ShortHelp()
+	if m.list.FilterState() != list.Filtering {
+		bindings = append(bindings, m.KeyMap.Select, m.KeyMap.Back)
+		if m.isNested() {
+			bindings = append(bindings, m.KeyMap.Root)
+		}
+		for _, item := range m.Items() {
+			if item.shortcut != nil && !item.disabled {
+				bindings = append(bindings, item.shortcutBinding())
+			}
+		}
+	}
+	return []keys.Group{{Title: m.list.Title, Bindings: bindings}}
+}
@@ -0,0 +1,83 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Funkit/theiere/subview"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lazyItem holds how to build the subview of an item created on selection.
+type lazyItem struct {
+	factory func() (subview.Model, error)
+	discard bool
+}
+
+// registerItem stores the subview of an item, or its factory when the subview is built lazily.
+func (m *Model) registerItem(item ListItem) error {
+	switch {
+	case item.Component != nil:
+		m.SubViews[item.Item.id] = item.Component
+	case item.Factory != nil:
+		m.SubViews[item.Item.id] = nil
+		m.lazy[item.Item.id] = lazyItem{factory: item.Factory, discard: item.Discard}
+	default:
+		return fmt.Errorf("missing component for menu item %q", item.Item.id)
+	}
+
+	return nil
+}
+
+// open displays the subview of an item, building and initializing it first if needed.
+func (m *Model) open(item Item) tea.Cmd {
+	if m.SubViews[item.id] != nil {
+		m.choice = item.id
+		return nil
+	}
+
+	lazy, ok := m.lazy[item.id]
+	if !ok {
+		return nil
+	}
+
+	component, err := lazy.factory()
+	if err != nil {
+		m.err = fmt.Errorf("cannot open %s: %w", item.title, err)
+		return nil
+	}
+	if component == nil {
+		m.err = fmt.Errorf("cannot open %s: no component built", item.title)
+		return nil
+	}
+
+	m.prepare(component, item.title)
+	m.SubViews[item.id] = component
+	m.choice = item.id
+
+	return component.Init()
+}
+
+// close goes back to the menu list from the displayed subview, discarding it if required.
+func (m *Model) close() {
+	if m.choice == "" {
+		return
+	}
+
+	m.SubViews[m.choice].Reset()
+	if lazy, ok := m.lazy[m.choice]; ok && lazy.discard {
+		m.SubViews[m.choice] = nil
+	}
+	m.choice = ""
+}
+
+// prepare sizes a subview added after the menu was sized, and sets the breadcrumbs of nested menus.
+func (m *Model) prepare(component subview.Model, title string) {
+	if m.width > 0 {
+		component.SetWidth(m.width)
+	}
+	if m.height > 0 {
+		component.SetHeight(m.height)
+	}
+	if sub, ok := component.(*Model); ok {
+		sub.setTrail(append(append([]string{}, m.trail...), title))
+	}
+}
@@ -1,34 +1,74 @@
 package menu
 
 import (
+	"fmt"
+	"github.com/Funkit/theiere/keys"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
 )
 
+// breadcrumbSeparator separates the levels of the breadcrumb trail displayed in nested menus.
+const breadcrumbSeparator = " › "
+
 // Model This is a list built for selecting various subviews.
 // Once a view is selected,the selected subview can trigger coming back
 // to the menu by returning common.TreeUp as a tea.Msg when updating.
+// A subview can itself be a menu, in which case its title shows the
+// breadcrumb trail from the root menu.
+// Subviews are keyed by item ID, and order holds these IDs in menu order.
 type Model struct {
-	list      list.Model
-	choice    string
-	SubViews  map[string]subview.Model
-	fixedSize bool
+	list       list.Model
+	delegate   list.ItemDelegate
+	choice     string
+	SubViews   map[string]subview.Model
+	order      []string
+	fixedSize  bool
+	title      string
+	trail      []string
+	id         string
+	width      int
+	height     int
+	lazy       map[string]lazyItem
+	err        error
+	errorStyle lipgloss.Style
+	KeyMap     KeyMap
 }
 
 type ListItem struct {
 	Item      Item
 	Component subview.Model
+	// Factory builds the subview when the item is selected, if Component is nil.
+	// The subview is then kept for the next selections, unless Discard is set.
+	Factory func() (subview.Model, error)
+	Discard bool
 }
 
 type options struct {
 	width     *int
 	height    *int
 	fixedSize bool
+	rootLabel *string
+	id        string
+	filtering bool
+	keyMap    *KeyMap
 }
 
 type Option func(options *options) error
 
+// WithKeyMap replaces the default key bindings.
+func WithKeyMap(keyMap KeyMap) Option {
+	return func(options *options) error {
+		options.keyMap = &keyMap
+
+		return nil
+	}
+}
+
 func WithWidth(width int) Option {
 	return func(options *options) error {
 		options.width = &width
@@ -53,6 +93,43 @@ func WithFixedSize() Option {
 	}
 }
 
+// WithRootLabel sets the name of this menu in the breadcrumb trail of its nested menus.
+// The menu title is used by default.
+func WithRootLabel(label string) Option {
+	return func(options *options) error {
+		options.rootLabel = &label
+
+		return nil
+	}
+}
+
+// WithFiltering enables fuzzy filtering of the items on their title, description and keywords.
+func WithFiltering() Option {
+	return func(options *options) error {
+		options.filtering = true
+
+		return nil
+	}
+}
+
+// WithMenuID sets the ID used to target this menu with item messages like AddItemMsg.
+func WithMenuID(id string) Option {
+	return func(options *options) error {
+		options.id = id
+
+		return nil
+	}
+}
+
+// ToRoot is sent to go back to the root menu from a nested menu.
+type ToRoot struct{}
+
+func (ToRoot) Upward() {}
+
+func GoRoot() tea.Msg {
+	return ToRoot{}
+}
+
 func New(title string, items []ListItem, opts ...Option) (Model, error) {
 	var options options
 	for _, opt := range opts {
@@ -72,64 +149,143 @@ func New(title string, items []ListItem, opts ...Option) (Model, error) {
 		height = *options.height
 	}
 
-	subViews := make(map[string]subview.Model)
+	rootLabel := title
+	if options.rootLabel != nil {
+		rootLabel = *options.rootLabel
+	}
+
+	keyMap := DefaultKeyMap()
+	if options.keyMap != nil {
+		keyMap = *options.keyMap
+	}
+
+	m := Model{
+		SubViews:  make(map[string]subview.Model),
+		lazy:      make(map[string]lazyItem),
+		fixedSize: options.fixedSize,
+		title:     title,
+		id:        options.id,
+		KeyMap:    keyMap,
+	}
 
 	var teaList []list.Item
 	for _, item := range items {
-		subViews[item.Item.title] = item.Component
+		if item.Item.id == "" {
+			return Model{}, fmt.Errorf("missing id for menu item %q", item.Item.title)
+		}
+		if _, exists := m.SubViews[item.Item.id]; exists {
+			return Model{}, fmt.Errorf("duplicate menu item id %q", item.Item.id)
+		}
+		if err := m.registerItem(item); err != nil {
+			return Model{}, err
+		}
+		m.order = append(m.order, item.Item.id)
 		teaList = append(teaList, item.Item)
 	}
 
-	l := list.New(teaList, list.NewDefaultDelegate(), width, height)
+	t := theme.Current()
+	m.delegate = newDelegate(t)
+	l := list.New(teaList, m.delegate, width, height)
 	l.Title = title
+	l.Styles.Title = l.Styles.Title.Copy().Foreground(t.TitleText).Background(t.Title)
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.KeyMap = keys.Apply("menu.list", l.KeyMap)
+	l.SetFilteringEnabled(options.filtering)
+	l.DisableQuitKeybindings()
+	// The bindings are shown by the frame help.
+	l.SetShowHelp(false)
+	l.KeyMap.ShowFullHelp = key.NewBinding()
+	l.KeyMap.CloseFullHelp = key.NewBinding()
 
-	return Model{
-		list:      l,
-		SubViews:  subViews,
-		fixedSize: options.fixedSize,
-	}, nil
+	m.list = l
+	m.errorStyle = lipgloss.NewStyle().Foreground(t.Danger).PaddingLeft(2)
+	m.setTrail([]string{rootLabel})
+
+	return m, nil
 }
 
 func (m *Model) Init() tea.Cmd {
 	var commands []tea.Cmd
-	for key := range m.SubViews {
-		commands = append(commands, m.SubViews[key].Init())
+	for _, id := range m.order {
+		if m.SubViews[id] != nil {
+			commands = append(commands, m.SubViews[id].Init())
+		}
 	}
 
 	return tea.Batch(commands...)
 }
 
 func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if handled, cmd := m.updateItems(msg); handled {
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case NavigateMsg:
+		return m, m.navigate(msg.Path)
+	case ToRoot:
+		m.close()
+		return m, nil
+	case subview.TreeUp:
+		if m.choice != "" && !m.childIsBrowsing() {
+			m.close()
+			return m, nil
+		}
+	}
+
 	if m.choice == "" {
-		m.list, _ = m.list.Update(msg)
+		// While typing a filter, keys belong to the filter input, and going back clears an applied filter.
+		filterState := m.list.FilterState()
+		keyMsg, isKey := msg.(tea.KeyMsg)
+		filterKey := filterState == list.Filtering ||
+			(isKey && filterState == list.FilterApplied && key.Matches(keyMsg, m.list.KeyMap.ClearFilter))
+		if mouseMsg, ok := msg.(tea.MouseMsg); ok && filterState != list.Filtering {
+			return m, m.updateMouse(mouseMsg)
+		}
+
+		// Shortcuts are checked first, so that they win over the list keys like j and k.
+		if isKey && !filterKey {
+			for _, listItem := range m.list.Items() {
+				if i, ok := listItem.(Item); ok && i.shortcut != nil && !i.disabled && key.Matches(keyMsg, *i.shortcut) {
+					m.err = nil
+					return m, m.open(i)
+				}
+			}
+		}
+
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
-			switch keypress := msg.String(); keypress {
-			case "enter":
+			m.err = nil
+			if filterKey {
+				return m, cmd
+			}
+
+			switch {
+			case key.Matches(msg, m.KeyMap.Select):
 				i, ok := m.list.SelectedItem().(Item)
-				if ok {
-					m.choice = i.Title()
+				if ok && !i.disabled {
+					return m, tea.Batch(cmd, m.open(i))
 				}
-				return m, nil
-			case "q", "esc", "ctrl+c":
+				return m, cmd
+			case key.Matches(msg, m.KeyMap.Back):
+				if m.isNested() {
+					return m, subview.GoUp
+				}
+				return m, tea.Quit
+			case key.Matches(msg, m.KeyMap.Root) && m.isNested():
+				return m, GoRoot
+			case msg.String() == "ctrl+c":
 				return m, tea.Quit
 			}
 		}
+		return m, cmd
 	}
 
 	var cmd tea.Cmd
 	if m.choice != "" {
 		m.SubViews[m.choice], cmd = m.SubViews[m.choice].Update(msg)
-		switch msg.(type) {
-		case subview.TreeUp:
-			m.choice = ""
-			for key := range m.SubViews {
-				m.SubViews[key].Reset()
-			}
-			return m, nil
-		}
 		return m, cmd
 	}
 
@@ -141,30 +297,99 @@ func (m *Model) View() string {
 		return m.SubViews[m.choice].View()
 	}
 
+	if m.err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, m.list.View(), m.errorStyle.Render(m.err.Error()))
+	}
+
 	return m.list.View()
 }
 
 func (m *Model) SetWidth(width int) {
 	if !m.fixedSize {
+		m.width = width
 		m.list.SetWidth(width)
-		for key := range m.SubViews {
-			m.SubViews[key].SetWidth(width)
+		for _, id := range m.order {
+			if m.SubViews[id] != nil {
+				m.SubViews[id].SetWidth(width)
+			}
 		}
 	}
 }
 
 func (m *Model) SetHeight(height int) {
 	if !m.fixedSize {
+		m.height = height
 		m.list.SetHeight(height)
-		for key := range m.SubViews {
-			m.SubViews[key].SetHeight(height)
+		for _, id := range m.order {
+			if m.SubViews[id] != nil {
+				m.SubViews[id].SetHeight(height)
+			}
 		}
 	}
 }
 
 func (m *Model) Reset() {
 	m.choice = ""
-	for key := range m.SubViews {
-		m.SubViews[key].Reset()
+	m.err = nil
+	for _, id := range m.order {
+		if m.SubViews[id] == nil {
+			continue
+		}
+		m.SubViews[id].Reset()
+		if lazy, ok := m.lazy[id]; ok && lazy.discard {
+			m.SubViews[id] = nil
+		}
+	}
+}
+
+// Breadcrumbs returns the trail of menus leading to the one currently displayed.
+func (m *Model) Breadcrumbs() []string {
+	if sub, ok := m.SubViews[m.choice].(*Model); ok && m.choice != "" {
+		return sub.Breadcrumbs()
+	}
+	return m.trail
+}
+
+// setTrail sets the breadcrumb trail leading to this menu, and to its nested menus.
+func (m *Model) setTrail(trail []string) {
+	m.trail = trail
+	if m.isNested() {
+		m.list.Title = strings.Join(trail, breadcrumbSeparator)
+	} else {
+		m.list.Title = m.title
+	}
+
+	for _, listItem := range m.list.Items() {
+		i, ok := listItem.(Item)
+		if !ok {
+			continue
+		}
+		if sub, ok := m.SubViews[i.id].(*Model); ok {
+			sub.setTrail(append(append([]string{}, trail...), i.title))
+		}
+	}
+}
+
+func (m *Model) isNested() bool {
+	return len(m.trail) > 1
+}
+
+// childIsBrowsing tells if the selected subview is a nested menu displaying one of its own subviews,
+// in which case going up must be handled by that menu.
+func (m *Model) childIsBrowsing() bool {
+	sub, ok := m.SubViews[m.choice].(*Model)
+	return ok && sub.choice != ""
+}
+
+// SetTheme applies a theme to the menu and to the subviews built so far. The subviews built later use the current theme.
+func (m *Model) SetTheme(t theme.Theme) {
+	m.delegate = newDelegate(t)
+	m.list.SetDelegate(m.delegate)
+	m.list.Styles.Title = m.list.Styles.Title.Copy().Foreground(t.TitleText).Background(t.Title)
+	m.errorStyle = m.errorStyle.Copy().Foreground(t.Danger)
+	for _, id := range m.order {
+		if m.SubViews[id] != nil {
+			theme.Apply(m.SubViews[id], t)
+		}
 	}
 }
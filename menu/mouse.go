@@ -0,0 +1,55 @@
+package menu
+
+import (
+	"github.com/Funkit/theiere/mouse"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// updateMouse moves the cursor with the wheel, and opens a clicked item.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.MouseWheelUp:
+		m.list.CursorUp()
+	case tea.MouseWheelDown:
+		m.list.CursorDown()
+	case tea.MouseLeft:
+		index, ok := m.itemAt(msg)
+		if !ok {
+			return nil
+		}
+		m.err = nil
+		m.list.Select(index)
+		if i, ok := m.list.SelectedItem().(Item); ok && !i.disabled {
+			return m.open(i)
+		}
+	}
+	return nil
+}
+
+// itemAt returns the index, among the visible items, of the item drawn at the position of the event.
+// The items are drawn below the title and status bars, each followed by the delegate spacing.
+func (m *Model) itemAt(msg tea.MouseMsg) (int, bool) {
+	top := 0
+	if m.list.ShowTitle() || (m.list.ShowFilter() && m.list.FilteringEnabled()) {
+		// The title bar holds the filter input, on a single line, while filtering.
+		title := 1
+		if m.list.FilterState() != list.Filtering {
+			title = lipgloss.Height(m.list.Styles.Title.Render(m.list.Title))
+		}
+		top += title + m.list.Styles.TitleBar.GetVerticalFrameSize()
+	}
+	if m.list.ShowStatusBar() {
+		// The status is a single line.
+		top += 1 + m.list.Styles.StatusBar.GetVerticalFrameSize()
+	}
+
+	d := m.delegate
+	step := d.Height() + d.Spacing()
+	items := m.list.Paginator.ItemsOnPage(len(m.list.VisibleItems()))
+	if !mouse.In(msg, 0, top, m.list.Width(), items*step) || (msg.Y-top)%step >= d.Height() {
+		return 0, false
+	}
+	return m.list.Paginator.Page*m.list.Paginator.PerPage + (msg.Y-top)/step, true
+}
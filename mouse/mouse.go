@@ -0,0 +1,40 @@
+package mouse
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"math"
+)
+
+// Translate returns the event in the coordinates of a component drawn at (x, y) in its container.
+// Containers translate the events before passing them to their content, so that each component
+// only deals with its own coordinates.
+func Translate(msg tea.MouseMsg, x, y int) tea.MouseMsg {
+	msg.X -= x
+	msg.Y -= y
+	return msg
+}
+
+// In tells whether the event is within the area of the given size drawn at (x, y).
+func In(msg tea.MouseMsg, x, y, width, height int) bool {
+	return msg.X >= x && msg.X < x+width && msg.Y >= y && msg.Y < y+height
+}
+
+// Offset returns the position of a block of the given size aligned in a larger area, the way lipgloss
+// places it with the alignment of a style or with Place.
+func Offset(area, size int, pos lipgloss.Position) int {
+	gap := area - size
+	switch {
+	case gap <= 0 || pos <= lipgloss.Left:
+		return 0
+	case pos >= lipgloss.Right:
+		return gap
+	default:
+		return gap - int(math.Round(float64(gap)*float64(pos)))
+	}
+}
+
+// Size returns the width and height of a rendered view.
+func Size(view string) (width, height int) {
+	return lipgloss.Width(view), lipgloss.Height(view)
+}
@@ -0,0 +1,67 @@
+package palette
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines keybindings. It satisfies the help.KeyMap interface.
+type KeyMap struct {
+	// Open shows the palette from any screen.
+	Open key.Binding
+
+	// Keybindings used while the palette is displayed.
+	Up    key.Binding
+	Down  key.Binding
+	Run   key.Binding
+	Close key.Binding
+}
+
+func init() {
+	keys.Define("palette", KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
+func DefaultKeyMap() KeyMap {
+	return keys.Apply("palette", KeyMap{
+		Open: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "commands"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "ctrl+k"),
+			key.WithHelp("↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "ctrl+j"),
+			key.WithHelp("↓", "down"),
+		),
+		Run: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "run"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc", "ctrl+p"),
+			key.WithHelp("esc", "close"),
+		),
+	})
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Up,
+		k.Down,
+		k.Run,
+		k.Close,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{
+		k.Open,
+		k.Up,
+		k.Down,
+		k.Run,
+		k.Close,
+	}}
+}
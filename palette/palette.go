@@ -0,0 +1,468 @@
+package palette
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/sahilm/fuzzy"
+	"strings"
+)
+
+var matchStyle = lipgloss.NewStyle().Underline(true)
+
+// Command is an action listed in the palette.
+type Command struct {
+	// ID identifies the command in the recently used list. The category and title are used when empty.
+	ID       string
+	Title    string
+	Category string
+	// Key runs the command directly when the palette is closed. It is optional.
+	Key key.Binding
+	// Run is returned when the command is chosen, like a message navigating to a screen.
+	Run tea.Cmd
+}
+
+func (c Command) id() string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return c.Category + "/" + c.Title
+}
+
+// Commander is implemented by the components registering commands in the palette.
+// Containers return the commands of their content along with their own.
+type Commander interface {
+	Commands() []Command
+}
+
+// Model is a command palette displayed over its content, listing the commands
+// registered with WithCommands and those of the content if it is a Commander.
+// Commands are fuzzy-searched on their category and title, the recently used ones being listed first.
+type Model struct {
+	Content       subview.Model
+	commands      []Command
+	visible       []Command
+	matches       [][]int
+	recent        []string
+	recentLimit   int
+	input         textinput.Model
+	open          bool
+	cursor        int
+	width, height int
+	boxStyle      lipgloss.Style
+	categoryStyle lipgloss.Style
+	keyStyle      lipgloss.Style
+	selectedStyle lipgloss.Style
+	KeyMap        KeyMap
+	Help          help.Model
+}
+
+type options struct {
+	commands    []Command
+	recentLimit int
+	keyMap      *KeyMap
+}
+
+type Option func(options *options) error
+
+// WithKeyMap replaces the default key bindings.
+func WithKeyMap(keyMap KeyMap) Option {
+	return func(options *options) error {
+		options.keyMap = &keyMap
+
+		return nil
+	}
+}
+
+// WithCommands registers commands in addition to the ones of the content.
+func WithCommands(commands ...Command) Option {
+	return func(options *options) error {
+		options.commands = append(options.commands, commands...)
+
+		return nil
+	}
+}
+
+// WithRecentLimit sets how many recently used commands are listed first. It defaults to 5.
+func WithRecentLimit(limit int) Option {
+	return func(options *options) error {
+		options.recentLimit = limit
+
+		return nil
+	}
+}
+
+func New(content subview.Model, opts ...Option) (Model, error) {
+	options := options{recentLimit: 5}
+	for _, opt := range opts {
+		err := opt(&options)
+		if err != nil {
+			return Model{}, err
+		}
+	}
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "Type a command"
+
+	keyMap := DefaultKeyMap()
+	if options.keyMap != nil {
+		keyMap = *options.keyMap
+	}
+
+	m := Model{
+		Content:     content,
+		commands:    options.commands,
+		recentLimit: options.recentLimit,
+		input:       input,
+		KeyMap:      keyMap,
+		Help:        help.New(),
+	}
+	m.setStyles(theme.Current())
+
+	return m, nil
+}
+
+func (m *Model) setStyles(t theme.Theme) {
+	m.boxStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1)
+	m.categoryStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	m.keyStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	m.selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.Content.Init()
+}
+
+func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if m.open {
+		return m.updateOpen(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && keys.Handles(keys.Global, msg, m.Content) {
+		if key.Matches(msg, m.KeyMap.Open) {
+			m.open = true
+			m.input.SetValue("")
+			m.filter()
+			return m, m.input.Focus()
+		}
+		for _, command := range m.Commands() {
+			if key.Matches(msg, command.Key) {
+				return m, m.run(command)
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.Content, cmd = m.Content.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) updateOpen(msg tea.Msg) (subview.Model, tea.Cmd) {
+	// The content is covered by the palette, and does not receive mouse events.
+	if _, ok := msg.(tea.MouseMsg); ok {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.Content, cmd = m.Content.Update(msg)
+		inputCmd := m.updateInput(msg)
+		return m, tea.Batch(cmd, inputCmd)
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.Close):
+		m.close()
+		return m, nil
+	case key.Matches(keyMsg, m.KeyMap.Up):
+		m.cursor = max(0, m.cursor-1)
+		return m, nil
+	case key.Matches(keyMsg, m.KeyMap.Down):
+		m.cursor = max(0, min(m.cursor+1, len(m.visible)-1))
+		return m, nil
+	case key.Matches(keyMsg, m.KeyMap.Run):
+		if len(m.visible) == 0 {
+			return m, nil
+		}
+		command := m.visible[m.cursor]
+		m.close()
+		return m, m.run(command)
+	}
+
+	value := m.input.Value()
+	cmd := m.updateInput(msg)
+	if m.input.Value() != value {
+		m.filter()
+	}
+	return m, cmd
+}
+
+func (m *Model) updateInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return cmd
+}
+
+func (m *Model) View() string {
+	if !m.open {
+		return m.Content.View()
+	}
+
+	return overlay(m.Content.View(), m.renderBox(), m.width)
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.Content.SetWidth(width)
+}
+
+func (m *Model) SetHeight(height int) {
+	m.height = height
+	m.Content.SetHeight(height)
+}
+
+func (m *Model) Reset() {
+	m.close()
+	m.Content.Reset()
+}
+
+// Commands returns the registered commands followed by the ones of the content.
+func (m *Model) Commands() []Command {
+	commands := append([]Command{}, m.commands...)
+	if commander, ok := m.Content.(Commander); ok {
+		commands = append(commands, commander.Commands()...)
+	}
+	return commands
+}
+
+// DeclareKeys declares the binding opening the palette and the command bindings as global, then the bindings
+// of the content. The bindings used while the palette is displayed are not declared, the palette being modal.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.Add(owner, keys.Global, m.KeyMap.Open)
+	for _, command := range m.Commands() {
+		registry.Add(owner, keys.Global, command.Key)
+	}
+	registry.Declare(m.Content, owner)
+}
+
+// CapturingInput tells whether the palette or its content is capturing text input.
+func (m *Model) CapturingInput() bool {
+	return m.open || keys.Capturing(m.Content)
+}
+
+// KeyHelp returns the bindings of the palette when it is displayed. Otherwise, it returns the binding opening it
+// and the command bindings, followed by the bindings of the content.
+func (m *Model) KeyHelp() []keys.Group {
+	if m.open {
+		return []keys.Group{{Title: "Command palette", Bindings: m.KeyMap.ShortHelp()}}
+	}
+
+	bindings := []key.Binding{m.KeyMap.Open}
+	for _, command := range m.Commands() {
+		bindings = append(bindings, command.Key)
+	}
+	return append([]keys.Group{{Title: "Commands", Bindings: bindings}}, keys.Help(m.Content)...)
+}
+
+// Recent returns the IDs of the recently used commands, the most recent first.
+func (m *Model) Recent() []string {
+	return m.recent
+}
+
+func (m *Model) close() {
+	m.open = false
+	m.input.Blur()
+	m.cursor = 0
+}
+
+// run records the command as recently used and returns its command.
+func (m *Model) run(command Command) tea.Cmd {
+	id := command.id()
+	recent := []string{id}
+	for _, r := range m.recent {
+		if r != id && len(recent) < m.recentLimit {
+			recent = append(recent, r)
+		}
+	}
+	m.recent = recent
+
+	return command.Run
+}
+
+// filter lists the commands matching the query. Without a query, the recently used commands come first.
+func (m *Model) filter() {
+	commands := m.Commands()
+	m.cursor = 0
+	m.visible = m.visible[:0]
+	m.matches = m.matches[:0]
+
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		rank := make(map[string]int)
+		for i, id := range m.recent {
+			rank[id] = i + 1
+		}
+		for _, id := range m.recent {
+			for _, command := range commands {
+				if command.id() == id {
+					m.visible = append(m.visible, command)
+					m.matches = append(m.matches, nil)
+					break
+				}
+			}
+		}
+		for _, command := range commands {
+			if rank[command.id()] == 0 {
+				m.visible = append(m.visible, command)
+				m.matches = append(m.matches, nil)
+			}
+		}
+		return
+	}
+
+	targets := make([]string, len(commands))
+	for i, command := range commands {
+		targets[i] = searchText(command)
+	}
+	for _, match := range fuzzy.Find(query, targets) {
+		m.visible = append(m.visible, commands[match.Index])
+		m.matches = append(m.matches, runeIndexes(match.Str, match.MatchedIndexes))
+	}
+}
+
+// runeIndexes converts the byte indexes of the fuzzy matches into rune indexes.
+func runeIndexes(s string, byteIndexes []int) []int {
+	runes := make(map[int]int)
+	i := 0
+	for b := range s {
+		runes[b] = i
+		i++
+	}
+
+	var indexes []int
+	for _, b := range byteIndexes {
+		indexes = append(indexes, runes[b])
+	}
+	return indexes
+}
+
+// searchText is the text matched by the query: the category followed by the title.
+func searchText(command Command) string {
+	if command.Category == "" {
+		return command.Title
+	}
+	return command.Category + ": " + command.Title
+}
+
+func (m *Model) renderBox() string {
+	width := max(20, min(60, m.width-4))
+	m.input.Width = width - 3
+
+	lines := []string{m.input.View(), ""}
+	listHeight := max(1, min(10, m.height-6))
+	start := max(0, m.cursor-listHeight+1)
+	for i := start; i < len(m.visible) && i < start+listHeight; i++ {
+		lines = append(lines, m.renderCommand(i, width))
+	}
+	if len(m.visible) == 0 {
+		lines = append(lines, m.categoryStyle.Render("No matching command"))
+	}
+	lines = append(lines, "", m.Help.View(m.KeyMap))
+
+	return m.boxStyle.Copy().Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func (m *Model) renderCommand(index, width int) string {
+	command := m.visible[index]
+
+	shortcut := ""
+	if help := command.Key.Help().Key; help != "" {
+		shortcut = " " + help
+	}
+
+	text := runewidth.Truncate(searchText(command), max(0, width-runewidth.StringWidth(shortcut)-2), "…")
+	runes := []rune(text)
+	matched := make(map[int]bool)
+	for _, i := range m.matches[index] {
+		matched[i] = true
+	}
+
+	categoryLength := 0
+	if command.Category != "" {
+		categoryLength = len([]rune(command.Category)) + 2
+	}
+
+	style := lipgloss.NewStyle()
+	if index == m.cursor {
+		style = m.selectedStyle
+	}
+
+	var b strings.Builder
+	for i, r := range runes {
+		s := style
+		if i < categoryLength && index != m.cursor {
+			s = m.categoryStyle
+		}
+		if matched[i] {
+			s = s.Copy().Inherit(matchStyle)
+		}
+		b.WriteString(s.Render(string(r)))
+	}
+
+	marker := "  "
+	if index == m.cursor {
+		marker = m.selectedStyle.Render("› ")
+	}
+	gap := strings.Repeat(" ", max(0, width-2-runewidth.StringWidth(text)-runewidth.StringWidth(shortcut)))
+
+	return marker + b.String() + gap + m.keyStyle.Render(shortcut)
+}
+
+// overlay draws the box over the top of the content, horizontally centered.
+func overlay(content, box string, width int) string {
+	lines := strings.Split(content, "\n")
+	boxLines := strings.Split(box, "\n")
+	left := strings.Repeat(" ", max(0, (width-lipgloss.Width(box))/2))
+
+	for i, boxLine := range boxLines {
+		row := i + 1
+		if row < len(lines) {
+			lines[row] = left + boxLine
+		} else {
+			lines = append(lines, left+boxLine)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// SetTheme applies a theme to the palette and to its content.
+func (m *Model) SetTheme(t theme.Theme) {
+	m.setStyles(t)
+	theme.Apply(m.Content, t)
+}
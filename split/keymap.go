@@ -0,0 +1,40 @@
+package split
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines the keybindings of the split pane. It satisfies the help.KeyMap interface.
+type KeyMap struct {
+	// SwitchPane gives the keys to the other pane.
+	SwitchPane key.Binding
+}
+
+func init() {
+	keys.Define("split", KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
+func DefaultKeyMap() KeyMap {
+	return keys.Apply("split", KeyMap{
+		SwitchPane: key.NewBinding(
+			key.WithKeys("f6"),
+			key.WithHelp("f6", "switch pane"),
+		),
+	})
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.SwitchPane,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{
+			k.SwitchPane,
+		},
+	}
+}
@@ -0,0 +1,382 @@
+package split
+
+import (
+	"fmt"
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/mouse"
+	"github.com/Funkit/theiere/palette"
+	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+// Orientation is the direction in which the panes are laid out.
+type Orientation int
+
+const (
+	// Horizontal places the panes side by side, split by a vertical divider.
+	Horizontal Orientation = iota
+	// Vertical stacks the panes, split by a horizontal divider.
+	Vertical
+)
+
+// Model is a split pane displaying two subviews next to each other. The divider between them
+// can be dragged with the mouse to resize them. Keys go to the focused pane, which is changed with
+// the switch key or by clicking a pane. The other messages go to both panes.
+type Model struct {
+	First       subview.Model
+	Second      subview.Model
+	KeyMap      KeyMap
+	orientation Orientation
+	width       int
+	height      int
+	// ratio is the share of the first pane, kept when the split is resized. firstSize is the resulting size.
+	ratio        float64
+	firstSize    int
+	minSize      int
+	focus        int
+	dragging     bool
+	dividerStyle lipgloss.Style
+	dragStyle    lipgloss.Style
+}
+
+type options struct {
+	width       *int
+	height      *int
+	orientation Orientation
+	ratio       *float64
+	minSize     *int
+	keyMap      *KeyMap
+}
+
+type Option func(options *options) error
+
+// WithKeyMap replaces the default key bindings.
+func WithKeyMap(keyMap KeyMap) Option {
+	return func(options *options) error {
+		options.keyMap = &keyMap
+
+		return nil
+	}
+}
+
+func WithWidth(width int) Option {
+	return func(options *options) error {
+		options.width = &width
+
+		return nil
+	}
+}
+
+func WithHeight(height int) Option {
+	return func(options *options) error {
+		options.height = &height
+
+		return nil
+	}
+}
+
+// WithOrientation sets how the panes are laid out, side by side by default.
+func WithOrientation(orientation Orientation) Option {
+	return func(options *options) error {
+		options.orientation = orientation
+
+		return nil
+	}
+}
+
+// WithRatio sets the share of the first pane, between 0 and 1 excluded. It defaults to 0.5.
+func WithRatio(ratio float64) Option {
+	return func(options *options) error {
+		if ratio <= 0 || ratio >= 1 {
+			return fmt.Errorf("invalid split ratio %v", ratio)
+		}
+		options.ratio = &ratio
+
+		return nil
+	}
+}
+
+// WithMinSize sets the size under which a pane cannot be shrunk by dragging the divider. It defaults to 5.
+func WithMinSize(size int) Option {
+	return func(options *options) error {
+		if size < 1 {
+			return fmt.Errorf("invalid minimum pane size %d", size)
+		}
+		options.minSize = &size
+
+		return nil
+	}
+}
+
+func New(first, second subview.Model, opts ...Option) (Model, error) {
+	var options options
+	for _, opt := range opts {
+		err := opt(&options)
+		if err != nil {
+			return Model{}, err
+		}
+	}
+
+	if first == nil || second == nil {
+		return Model{}, fmt.Errorf("missing pane content")
+	}
+
+	width := 60
+	if options.width != nil {
+		width = *options.width
+	}
+
+	height := 20
+	if options.height != nil {
+		height = *options.height
+	}
+
+	ratio := 0.5
+	if options.ratio != nil {
+		ratio = *options.ratio
+	}
+
+	minSize := 5
+	if options.minSize != nil {
+		minSize = *options.minSize
+	}
+
+	keyMap := DefaultKeyMap()
+	if options.keyMap != nil {
+		keyMap = *options.keyMap
+	}
+
+	m := Model{
+		First:       first,
+		Second:      second,
+		KeyMap:      keyMap,
+		orientation: options.orientation,
+		width:       width,
+		height:      height,
+		ratio:       ratio,
+		minSize:     minSize,
+	}
+	m.setStyles(theme.Current())
+	m.resize()
+
+	return m, nil
+}
+
+func (m *Model) setStyles(t theme.Theme) {
+	m.dividerStyle = lipgloss.NewStyle().Foreground(t.Border)
+	m.dragStyle = lipgloss.NewStyle().Foreground(t.Accent)
+}
+
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(m.First.Init(), m.Second.Init())
+}
+
+func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		focused := m.pane(m.focus)
+		if keys.Handles(keys.Screen, msg, *focused) && key.Matches(msg, m.KeyMap.SwitchPane) {
+			m.focus = 1 - m.focus
+			return m, nil
+		}
+		var cmd tea.Cmd
+		*focused, cmd = (*focused).Update(msg)
+		return m, cmd
+	case tea.MouseMsg:
+		return m, m.updateMouse(msg)
+	}
+
+	var first, second tea.Cmd
+	m.First, first = m.First.Update(msg)
+	m.Second, second = m.Second.Update(msg)
+	return m, tea.Batch(first, second)
+}
+
+// updateMouse drags the divider, or passes the event to the pane under it, focusing the clicked pane.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	position := msg.X
+	if m.orientation == Vertical {
+		position = msg.Y
+	}
+
+	if m.dragging {
+		switch msg.Type {
+		case tea.MouseLeft, tea.MouseMotion:
+			m.drag(position)
+		case tea.MouseRelease:
+			m.dragging = false
+		}
+		return nil
+	}
+
+	switch {
+	case position == m.firstSize:
+		m.dragging = msg.Type == tea.MouseLeft
+		return nil
+	case position < m.firstSize:
+		return m.updatePane(0, msg, 0)
+	default:
+		return m.updatePane(1, msg, m.firstSize+1)
+	}
+}
+
+// updatePane passes an event to a pane drawn at the given offset along the split.
+func (m *Model) updatePane(index int, msg tea.MouseMsg, offset int) tea.Cmd {
+	if msg.Type == tea.MouseLeft {
+		m.focus = index
+	}
+	x, y := offset, 0
+	if m.orientation == Vertical {
+		x, y = 0, offset
+	}
+
+	pane := m.pane(index)
+	var cmd tea.Cmd
+	*pane, cmd = (*pane).Update(mouse.Translate(msg, x, y))
+	return cmd
+}
+
+// drag moves the divider to a position, keeping both panes above the minimum size when there is room.
+func (m *Model) drag(position int) {
+	available := m.length() - 1
+	if available <= 0 {
+		return
+	}
+	position = max(position, min(m.minSize, available/2))
+	position = min(position, available-min(m.minSize, available/2))
+	m.ratio = float64(position) / float64(available)
+	m.resize()
+}
+
+func (m *Model) pane(index int) *subview.Model {
+	if index == 0 {
+		return &m.First
+	}
+	return &m.Second
+}
+
+// length returns the size of the split along its orientation.
+func (m *Model) length() int {
+	if m.orientation == Vertical {
+		return m.height
+	}
+	return m.width
+}
+
+// resize shares the room left by the divider between the panes according to the ratio.
+func (m *Model) resize() {
+	m.firstSize = int(float64(max(0, m.length()-1))*m.ratio + 0.5)
+	second := m.secondSize()
+
+	if m.orientation == Vertical {
+		m.First.SetWidth(m.width)
+		m.First.SetHeight(m.firstSize)
+		m.Second.SetWidth(m.width)
+		m.Second.SetHeight(second)
+		return
+	}
+	m.First.SetWidth(m.firstSize)
+	m.First.SetHeight(m.height)
+	m.Second.SetWidth(second)
+	m.Second.SetHeight(m.height)
+}
+
+// secondSize returns the room left to the second pane by the first one and the divider.
+func (m *Model) secondSize() int {
+	return max(0, m.length()-1-m.firstSize)
+}
+
+func (m *Model) View() string {
+	style := m.dividerStyle
+	if m.dragging {
+		style = m.dragStyle
+	}
+
+	// The panes are drawn in boxes of their exact size, so that the divider stays in place.
+	if m.orientation == Vertical {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			box(m.width, m.firstSize).Render(m.First.View()),
+			style.Render(strings.Repeat("─", m.width)),
+			box(m.width, m.secondSize()).Render(m.Second.View()))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		box(m.firstSize, m.height).Render(m.First.View()),
+		style.Render(strings.TrimSuffix(strings.Repeat("│\n", m.height), "\n")),
+		box(m.secondSize(), m.height).Render(m.Second.View()))
+}
+
+func box(width, height int) lipgloss.Style {
+	return lipgloss.NewStyle().Width(width).Height(height).MaxWidth(width).MaxHeight(height)
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.resize()
+}
+
+func (m *Model) SetHeight(height int) {
+	m.height = height
+	m.resize()
+}
+
+func (m *Model) Reset() {
+	m.focus = 0
+	m.dragging = false
+	m.First.Reset()
+	m.Second.Reset()
+}
+
+// Commands returns the palette commands of both panes.
+func (m *Model) Commands() []palette.Command {
+	var commands []palette.Command
+	for _, pane := range []subview.Model{m.First, m.Second} {
+		if commander, ok := pane.(palette.Commander); ok {
+			commands = append(commands, commander.Commands()...)
+		}
+	}
+	return commands
+}
+
+// DeclareKeys declares the binding switching panes, then the bindings of both panes.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.AddKeyMap(owner, keys.Screen, m.KeyMap)
+	registry.Declare(m.First, owner+"/first")
+	registry.Declare(m.Second, owner+"/second")
+}
+
+// CapturingInput tells whether the focused pane is capturing text input.
+func (m *Model) CapturingInput() bool {
+	return keys.Capturing(*m.pane(m.focus))
+}
+
+// KeyHelp returns the binding switching panes, followed by the bindings of the focused pane.
+func (m *Model) KeyHelp() []keys.Group {
+	groups := []keys.Group{{Title: "Split", Bindings: m.KeyMap.ShortHelp()}}
+	return append(groups, keys.Help(*m.pane(m.focus))...)
+}
+
+// SetTheme applies a theme to the divider and to both panes.
+func (m *Model) SetTheme(t theme.Theme) {
+	m.setStyles(t)
+	theme.Apply(m.First, t)
+	theme.Apply(m.Second, t)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,170 @@
+package split
+
+import (
+	"github.com/Funkit/theiere/subview"
+	tea "github.com/charmbracelet/bubbletea"
+	"testing"
+)
+
+// pane records the size it is given and the mouse events it receives.
+type pane struct {
+	width, height int
+	clicks        []tea.MouseMsg
+}
+
+func (p *pane) SetWidth(width int)   { p.width = width }
+func (p *pane) SetHeight(height int) { p.height = height }
+func (p *pane) View() string         { return "" }
+func (p *pane) Init() tea.Cmd        { return nil }
+func (p *pane) Reset()               {}
+
+func (p *pane) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		p.clicks = append(p.clicks, msg)
+	}
+	return p, nil
+}
+
+func newSplit(t *testing.T, opts ...Option) (*Model, *pane, *pane) {
+	t.Helper()
+	first, second := &pane{}, &pane{}
+	m, err := New(first, second, append([]Option{WithWidth(61), WithHeight(10)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &m, first, second
+}
+
+func press(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Type: tea.MouseLeft}
+}
+
+func TestNewSizesPanes(t *testing.T) {
+	m, first, second := newSplit(t, WithRatio(0.25))
+
+	// 60 columns are left by the divider.
+	if m.firstSize != 15 || first.width != 15 || second.width != 45 {
+		t.Errorf("sizes = %d, %d, %d, want 15, 15, 45", m.firstSize, first.width, second.width)
+	}
+	if first.height != 10 || second.height != 10 {
+		t.Errorf("heights = %d, %d, want 10, 10", first.height, second.height)
+	}
+}
+
+func TestDividerHitTest(t *testing.T) {
+	tests := []struct {
+		name     string
+		x        int
+		dragging bool
+		focus    int
+	}{
+		{"left of the divider", 29, false, 0},
+		{"on the divider", 30, true, 0},
+		{"right of the divider", 31, false, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, _, _ := newSplit(t)
+			m.Update(press(test.x, 3))
+
+			if m.dragging != test.dragging {
+				t.Errorf("dragging = %v, want %v", m.dragging, test.dragging)
+			}
+			if m.focus != test.focus {
+				t.Errorf("focus = %d, want %d", m.focus, test.focus)
+			}
+		})
+	}
+}
+
+func TestClicksAreTranslatedToThePane(t *testing.T) {
+	m, first, second := newSplit(t)
+
+	m.Update(press(10, 3))
+	m.Update(press(40, 4))
+
+	if len(first.clicks) != 1 || first.clicks[0].X != 10 || first.clicks[0].Y != 3 {
+		t.Errorf("first pane clicks = %v, want one at 10,3", first.clicks)
+	}
+	// The second pane starts after the first one and the divider.
+	if len(second.clicks) != 1 || second.clicks[0].X != 9 || second.clicks[0].Y != 4 {
+		t.Errorf("second pane clicks = %v, want one at 9,4", second.clicks)
+	}
+}
+
+func TestDragMovesDivider(t *testing.T) {
+	tests := []struct {
+		name      string
+		to        int
+		firstSize int
+		ratio     float64
+	}{
+		{"right", 45, 45, 0.75},
+		{"left", 12, 12, 0.2},
+		{"past the left edge", 0, 5, 5.0 / 60},
+		{"past the right edge", 80, 55, 55.0 / 60},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, first, second := newSplit(t)
+			m.Update(press(30, 0))
+			m.Update(tea.MouseMsg{X: test.to, Y: 0, Type: tea.MouseMotion})
+			m.Update(tea.MouseMsg{X: test.to, Y: 0, Type: tea.MouseRelease})
+
+			if m.dragging {
+				t.Error("still dragging after the release")
+			}
+			if m.firstSize != test.firstSize || m.ratio != test.ratio {
+				t.Errorf("first size = %d, ratio = %v, want %d, %v", m.firstSize, m.ratio, test.firstSize, test.ratio)
+			}
+			if first.width != test.firstSize || second.width != 60-test.firstSize {
+				t.Errorf("pane widths = %d, %d, want %d, %d", first.width, second.width, test.firstSize, 60-test.firstSize)
+			}
+			if len(first.clicks)+len(second.clicks) != 0 {
+				t.Error("the drag was passed to the panes")
+			}
+		})
+	}
+}
+
+func TestDragKeepsRatioOnResize(t *testing.T) {
+	m, first, _ := newSplit(t)
+	m.Update(press(30, 0))
+	m.Update(press(15, 0))
+	m.Update(tea.MouseMsg{X: 15, Y: 0, Type: tea.MouseRelease})
+
+	m.SetWidth(121)
+	if first.width != 30 {
+		t.Errorf("first width = %d, want 30", first.width)
+	}
+}
+
+func TestVerticalSplitUsesRows(t *testing.T) {
+	m, first, second := newSplit(t, WithOrientation(Vertical), WithHeight(21), WithMinSize(2))
+
+	if m.firstSize != 10 || first.height != 10 || second.height != 10 || first.width != 61 {
+		t.Fatalf("sizes = %d, %dx%d, %d, want 10, 61x10, 10", m.firstSize, first.width, first.height, second.height)
+	}
+
+	m.Update(press(5, 10))
+	if !m.dragging {
+		t.Fatal("pressing the divider row did not start a drag")
+	}
+	m.Update(press(5, 4))
+	if m.firstSize != 4 {
+		t.Errorf("first size = %d, want 4", m.firstSize)
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	for _, opt := range []Option{WithRatio(0), WithRatio(1), WithMinSize(0)} {
+		if _, err := New(&pane{}, &pane{}, opt); err == nil {
+			t.Error("expected an error")
+		}
+	}
+	if _, err := New(nil, &pane{}); err == nil {
+		t.Error("expected an error for a missing pane")
+	}
+}
@@ -1,13 +1,18 @@
 package subframe
 
 import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/mouse"
+	"github.com/Funkit/theiere/palette"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 type Model struct {
-	borderColor lipgloss.AdaptiveColor
+	border      bool
+	borderColor *lipgloss.AdaptiveColor
 	Style       lipgloss.Style
 	Content     subview.Model
 	hasContent  bool
@@ -130,7 +135,7 @@ func New(opts ...Option) (Model, error) {
 		Height(height).
 		AlignHorizontal(horizontalAlignment).AlignVertical(verticalAlignment)
 	if options.border {
-		color := lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"}
+		color := theme.Current().Border
 		if options.borderColor != nil {
 			color = *options.borderColor
 		}
@@ -139,8 +144,10 @@ func New(opts ...Option) (Model, error) {
 	}
 
 	m := Model{
-		Style:     style,
-		fixedSize: fixedSize,
+		border:      options.border,
+		borderColor: options.borderColor,
+		Style:       style,
+		fixedSize:   fixedSize,
 	}
 
 	if options.component != nil {
@@ -184,9 +191,17 @@ func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
+	case tea.MouseMsg:
+		if !m.hasContent {
+			return m, nil
+		}
+		x, y := m.contentOffset()
+		var cmd tea.Cmd
+		m.Content, cmd = m.Content.Update(mouse.Translate(recv, x, y))
+		return m, cmd
 	case tea.KeyMsg:
-		switch recv.String() {
-		case "ctrl+c":
+		// Like the quit binding of the frame, which catches it first, so that a subframe used alone can be left.
+		if recv.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
 	}
@@ -214,8 +229,57 @@ func (m *Model) SetHeight(height int) {
 	}
 }
 
+// contentOffset returns where the content is drawn: inside the border, aligned in the frame.
+func (m *Model) contentOffset() (x, y int) {
+	if m.border {
+		x, y = 1, 1
+	}
+	width, height := mouse.Size(m.Content.View())
+	x += mouse.Offset(m.Style.GetWidth(), width, m.Style.GetAlignHorizontal())
+	y += mouse.Offset(m.Style.GetHeight(), height, m.Style.GetAlignVertical())
+	return x, y
+}
+
 func (m *Model) Reset() {
 	if m.hasContent {
 		m.Content.Reset()
 	}
 }
+
+// Commands returns the palette commands of the content, if it registers any.
+func (m *Model) Commands() []palette.Command {
+	if commander, ok := m.Content.(palette.Commander); ok && m.hasContent {
+		return commander.Commands()
+	}
+	return nil
+}
+
+// DeclareKeys declares the bindings of the content.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	if m.hasContent {
+		registry.Declare(m.Content, owner)
+	}
+}
+
+// CapturingInput tells whether the content is capturing text input.
+func (m *Model) CapturingInput() bool {
+	return m.hasContent && keys.Capturing(m.Content)
+}
+
+// KeyHelp returns the bindings of the content.
+func (m *Model) KeyHelp() []keys.Group {
+	if !m.hasContent {
+		return nil
+	}
+	return keys.Help(m.Content)
+}
+
+// SetTheme applies a theme to the border, unless its color was set with WithBorderColor, and to the content.
+func (m *Model) SetTheme(t theme.Theme) {
+	if m.border && m.borderColor == nil {
+		m.Style = m.Style.Copy().BorderForeground(t.Border)
+	}
+	if m.hasContent {
+		theme.Apply(m.Content, t)
+	}
+}
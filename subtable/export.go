@@ -0,0 +1,183 @@
+package subtable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportFormat is a file format the table contents can be exported to.
+type ExportFormat int
+
+const (
+	CSV ExportFormat = iota
+	TSV
+	JSON
+	Markdown
+)
+
+// ExportFormatFromPath guesses the export format from the extension of a file path.
+// Unknown extensions default to CSV.
+func ExportFormatFromPath(path string) ExportFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		return TSV
+	case ".json":
+		return JSON
+	case ".md", ".markdown":
+		return Markdown
+	default:
+		return CSV
+	}
+}
+
+// Exported is sent once the table contents have been exported from the table view.
+type Exported struct {
+	Path string
+	Rows int
+	Err  error
+}
+
+// Export writes the current rows and the visible columns of the table to w, as displayed: hidden
+// columns and the columns scrolled out of view are left out. Raw values are exported, without the
+// column formatters applied.
+func (m *Model) Export(w io.Writer, format ExportFormat) error {
+	columns := m.visibleColumns()
+
+	titles := make([]string, 0, len(columns))
+	for _, i := range columns {
+		titles = append(titles, m.columns[i].Title)
+	}
+
+	records := make([][]string, 0, len(m.rows))
+	for row := range m.rows {
+		record := make([]string, 0, len(columns))
+		for _, i := range columns {
+			record = append(record, m.cellValue(row, i))
+		}
+		records = append(records, record)
+	}
+
+	switch format {
+	case CSV, TSV:
+		writer := csv.NewWriter(w)
+		if format == TSV {
+			writer.Comma = '\t'
+		}
+		if err := writer.Write(titles); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(records); err != nil {
+			return err
+		}
+		return nil
+	case JSON:
+		objects := make([]orderedObject, 0, len(records))
+		for _, record := range records {
+			objects = append(objects, orderedObject{keys: titles, values: record})
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(objects)
+	case Markdown:
+		return writeMarkdown(w, titles, records)
+	default:
+		return fmt.Errorf("unknown export format %d", format)
+	}
+}
+
+// ExportFile writes the current rows and columns of the table to a file, the format
+// being deduced from the file extension.
+func (m *Model) ExportFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Export(f, ExportFormatFromPath(path)); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// exportCmd exports the table in the background and reports the result through an Exported message.
+func (m *Model) exportCmd(path string) tea.Cmd {
+	snapshot := *m
+	return func() tea.Msg {
+		return Exported{
+			Path: path,
+			Rows: len(snapshot.rows),
+			Err:  snapshot.ExportFile(path),
+		}
+	}
+}
+
+// orderedObject is a JSON object whose keys are written in the order of the table columns.
+type orderedObject struct {
+	keys   []string
+	values []string
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func writeMarkdown(w io.Writer, titles []string, records [][]string) error {
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+
+	writeLine := func(cells []string) error {
+		escaped := make([]string, 0, len(cells))
+		for _, cell := range cells {
+			escaped = append(escaped, escape.Replace(cell))
+		}
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+		return err
+	}
+
+	if err := writeLine(titles); err != nil {
+		return err
+	}
+
+	separator := make([]string, len(titles))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	if err := writeLine(separator); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if err := writeLine(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
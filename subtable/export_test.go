@@ -0,0 +1,104 @@
+package subtable
+
+import (
+	"bytes"
+	"github.com/charmbracelet/bubbles/table"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func exportModel(t *testing.T) Model {
+	t.Helper()
+	m, err := New(
+		WithColumns([]table.Column{{Title: "Name", Width: 10}, {Title: "UID", Width: 10}, {Title: "Memory", Width: 10}}),
+		WithRows([]table.Row{{"api", "u1", "2048"}, {"web, \"edge\"", "u2", "1|2\n3"}, {"short"}}),
+		WithHiddenColumns(1),
+		WithColumnFormatter(2, FormatBytes),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ExportFormat
+		want   string
+	}{
+		{"csv", CSV, "Name,Memory\napi,2048\n\"web, \"\"edge\"\"\",\"1|2\n3\"\nshort,\n"},
+		{"tsv", TSV, "Name\tMemory\napi\t2048\n\"web, \"\"edge\"\"\"\t\"1|2\n3\"\nshort\t\n"},
+		{"json", JSON, `[
+  {
+    "Name": "api",
+    "Memory": "2048"
+  },
+  {
+    "Name": "web, \"edge\"",
+    "Memory": "1|2\n3"
+  },
+  {
+    "Name": "short",
+    "Memory": ""
+  }
+]
+`},
+		{"markdown", Markdown, "| Name | Memory |\n| --- | --- |\n| api | 2048 |\n| web, \"edge\" | 1\\|2 3 |\n| short |  |\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := exportModel(t)
+			var b bytes.Buffer
+			if err := m.Export(&b, test.format); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != test.want {
+				t.Errorf("export = %q, want %q", b.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	m := exportModel(t)
+	if err := m.Export(&bytes.Buffer{}, ExportFormat(42)); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestExportFile(t *testing.T) {
+	m := exportModel(t)
+	path := filepath.Join(t.TempDir(), "pods.md")
+	if err := m.ExportFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| Name | Memory |\n"; !bytes.HasPrefix(data, []byte(want)) {
+		t.Errorf("file = %q, want a Markdown table", data)
+	}
+}
+
+func TestExportFormatFromPath(t *testing.T) {
+	tests := map[string]ExportFormat{
+		"pods.csv":      CSV,
+		"pods.TSV":      TSV,
+		"pods.tab":      TSV,
+		"pods.json":     JSON,
+		"pods.md":       Markdown,
+		"pods.markdown": Markdown,
+		"pods.txt":      CSV,
+		"pods":          CSV,
+	}
+	for path, want := range tests {
+		if got := ExportFormatFromPath(path); got != want {
+			t.Errorf("ExportFormatFromPath(%q) = %d, want %d", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package subtable
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decimalNumber matches the plain decimal numbers FormatThousands separates: no exponent, no hexadecimal, no
+// infinity or NaN.
+var decimalNumber = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// Formatter transforms a raw cell value into the text displayed in the table.
+// Formatters are applied at render time, the rows keep their raw values.
+type Formatter func(value string) string
+
+// FormatThousands adds thousand separators to numeric values. Other values are returned as is.
+func FormatThousands(value string) string {
+	number := strings.TrimSpace(value)
+	if !decimalNumber.MatchString(number) {
+		return value
+	}
+
+	sign := ""
+	if strings.HasPrefix(number, "-") || strings.HasPrefix(number, "+") {
+		sign, number = number[:1], number[1:]
+	}
+
+	integer, decimals := number, ""
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		integer, decimals = number[:i], number[i:]
+	}
+
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+
+	return sign + b.String() + decimals
+}
+
+// FormatDuration displays durations in a human-readable way. The value can either be a number of
+// seconds or a duration understood by time.ParseDuration. Other values are returned as is.
+func FormatDuration(value string) string {
+	number := strings.TrimSpace(value)
+
+	var d time.Duration
+	if seconds, err := strconv.ParseFloat(number, 64); err == nil {
+		d = time.Duration(seconds * float64(time.Second))
+	} else if parsed, err := time.ParseDuration(number); err == nil {
+		d = parsed
+	} else {
+		return value
+	}
+
+	if d >= time.Second {
+		d = d.Round(time.Second)
+	}
+
+	return d.String()
+}
+
+// FormatBytes displays a number of bytes using binary units (KiB, MiB...). Other values are returned as is.
+func FormatBytes(value string) string {
+	size, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return value
+	}
+
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	i := 0
+	for ; (size >= 1024 || size <= -1024) && i < len(units)-1; i++ {
+		size /= 1024
+	}
+
+	if i == 0 {
+		return fmt.Sprintf("%d %s", int64(size), units[i])
+	}
+
+	return fmt.Sprintf("%.1f %s", size, units[i])
+}
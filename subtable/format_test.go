@@ -0,0 +1,59 @@
+package subtable
+
+import "testing"
+
+func testFormatter(t *testing.T, formatter Formatter, tests map[string]string) {
+	t.Helper()
+	for value, want := range tests {
+		if got := formatter(value); got != want {
+			t.Errorf("format(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestFormatThousands(t *testing.T) {
+	testFormatter(t, FormatThousands, map[string]string{
+		"0":          "0",
+		"999":        "999",
+		"1000":       "1,000",
+		"1234567":    "1,234,567",
+		"-1234.5678": "-1,234.5678",
+		"+100000":    "+100,000",
+		" 2500 ":     "2,500",
+		"1e6":        "1e6",
+		"0x1000":     "0x1000",
+		"NaN":        "NaN",
+		"12.":        "12.",
+		"n/a":        "n/a",
+	})
+}
+
+func TestFormatDuration(t *testing.T) {
+	testFormatter(t, FormatDuration, map[string]string{
+		"0":        "0s",
+		"0.25":     "250ms",
+		"1.6":      "2s",
+		"90":       "1m30s",
+		"86400":    "24h0m0s",
+		"2h30m":    "2h30m0s",
+		"1500ms":   "2s",
+		"forever":  "forever",
+		" 45 ":     "45s",
+		"":         "",
+		"1 minute": "1 minute",
+	})
+}
+
+func TestFormatBytes(t *testing.T) {
+	testFormatter(t, FormatBytes, map[string]string{
+		"0":                   "0 B",
+		"1023":                "1023 B",
+		"1024":                "1.0 KiB",
+		"1536":                "1.5 KiB",
+		"1073741824":          "1.0 GiB",
+		"-2048":               "-2.0 KiB",
+		"1152921504606846976": "1.0 EiB",
+		"5e21":                "4336.8 EiB",
+		"lots":                "lots",
+	})
+}
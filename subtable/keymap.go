@@ -1,6 +1,7 @@
 package subtable
 
 import (
+	"github.com/Funkit/theiere/keys"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 )
@@ -9,6 +10,11 @@ type KeyMap interface {
 	ShortHelp() []key.Binding
 	FullHelp() [][]key.Binding
 	asInternalTableMap() table.KeyMap
+	scrollBindings() (left, right key.Binding)
+	exportBinding() key.Binding
+	selectBinding() key.Binding
+	treeBindings(scroll bool) (expand, collapse key.Binding)
+	quitBinding() key.Binding
 }
 
 type KM struct {
@@ -20,11 +26,25 @@ type KM struct {
 	HalfPageDown key.Binding
 	GotoTop      key.Binding
 	GotoBottom   key.Binding
+	ScrollLeft   key.Binding
+	ScrollRight  key.Binding
+	Export       key.Binding
+	Select       key.Binding
+	Expand       key.Binding
+	Collapse     key.Binding
+	// ScrollExpand and ScrollCollapse replace Expand and Collapse when the arrows scroll horizontally.
+	ScrollExpand   key.Binding
+	ScrollCollapse key.Binding
+	Quit           key.Binding
 }
 
-// DefaultKeyMap returns a default set of keybindings.
+func init() {
+	keys.Define("subtable", KM{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
 func DefaultKeyMap() KM {
-	return KM{
+	return keys.Apply("subtable", KM{
 		LineUp: key.NewBinding(
 			key.WithKeys("up"),
 			key.WithHelp("↑", "up"),
@@ -57,7 +77,43 @@ func DefaultKeyMap() KM {
 			key.WithKeys("b"),
 			key.WithHelp("b", "go to bottom"),
 		),
-	}
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", "scroll right"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "export"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Expand: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", "expand"),
+		),
+		Collapse: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", "collapse"),
+		),
+		ScrollExpand: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "expand"),
+		),
+		ScrollCollapse: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "collapse"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q", "quit"),
+		),
+	})
 }
 
 func (k KM) ShortHelp() []key.Binding {
@@ -89,6 +145,19 @@ func (k KM) FullHelp() [][]key.Binding {
 			k.GotoTop,
 			k.GotoBottom,
 		},
+		{
+			k.ScrollLeft,
+			k.ScrollRight,
+		},
+		{
+			k.Expand,
+			k.Collapse,
+		},
+		{
+			k.Select,
+			k.Export,
+			k.Quit,
+		},
 	}
 }
 
@@ -104,3 +173,26 @@ func (k KM) asInternalTableMap() table.KeyMap {
 		GotoBottom:   k.GotoBottom,
 	}
 }
+
+func (k KM) scrollBindings() (left, right key.Binding) {
+	return k.ScrollLeft, k.ScrollRight
+}
+
+func (k KM) exportBinding() key.Binding {
+	return k.Export
+}
+
+func (k KM) selectBinding() key.Binding {
+	return k.Select
+}
+
+func (k KM) treeBindings(scroll bool) (expand, collapse key.Binding) {
+	if scroll {
+		return k.ScrollExpand, k.ScrollCollapse
+	}
+	return k.Expand, k.Collapse
+}
+
+func (k KM) quitBinding() key.Binding {
+	return k.Quit
+}
@@ -0,0 +1,58 @@
+package subtable
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// DeclareKeys declares the bindings of the table. Scrolling and tree bindings are declared only when enabled.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	tableKeys := m.KeyMap.asInternalTableMap()
+	registry.Add(owner, keys.Component,
+		tableKeys.LineUp, tableKeys.LineDown,
+		tableKeys.PageUp, tableKeys.PageDown,
+		tableKeys.HalfPageUp, tableKeys.HalfPageDown,
+		tableKeys.GotoTop, tableKeys.GotoBottom,
+		m.KeyMap.selectBinding(), m.KeyMap.exportBinding(), m.KeyMap.quitBinding(),
+	)
+	if m.horizontalScroll {
+		left, right := m.KeyMap.scrollBindings()
+		registry.Add(owner, keys.Component, left, right)
+	}
+	if m.treeRoots != nil {
+		expand, collapse := m.KeyMap.treeBindings(m.horizontalScroll)
+		registry.Add(owner, keys.Component, expand, collapse)
+	}
+}
+
+// CapturingInput tells whether the export path is being typed.
+func (m *Model) CapturingInput() bool {
+	return m.exporting
+}
+
+// KeyHelp returns the enabled bindings of the table, or the ones of the export prompt while the path is typed.
+func (m *Model) KeyHelp() []keys.Group {
+	if m.exporting {
+		return []keys.Group{{Title: "Export", Bindings: []key.Binding{exportConfirmBinding, exportCancelBinding}}}
+	}
+
+	tableKeys := m.KeyMap.asInternalTableMap()
+	bindings := []key.Binding{tableKeys.LineUp, tableKeys.LineDown, tableKeys.PageUp, tableKeys.PageDown,
+		tableKeys.GotoTop, tableKeys.GotoBottom}
+	if m.treeRoots != nil {
+		expand, collapse := m.KeyMap.treeBindings(m.horizontalScroll)
+		bindings = append(bindings, expand, collapse)
+	}
+	if m.horizontalScroll {
+		left, right := m.KeyMap.scrollBindings()
+		bindings = append(bindings, left, right)
+	}
+	bindings = append(bindings, m.KeyMap.selectBinding(), m.KeyMap.exportBinding(), m.KeyMap.quitBinding())
+
+	return []keys.Group{{Title: "Table", Bindings: bindings}}
+}
+
+var (
+	exportConfirmBinding = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "export"))
+	exportCancelBinding  = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel"))
+)
@@ -0,0 +1,251 @@
+package subtable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/mattn/go-runewidth"
+	"io"
+	"strings"
+)
+
+// maxInferredWidth caps the width inferred from the content of a column.
+const maxInferredWidth = 40
+
+// FromCSV builds a table from comma-separated values, the first record being used as header.
+// Columns are ordered as set by WithColumnOrder, the remaining ones in order of appearance.
+// The column widths are inferred from the content.
+func FromCSV(r io.Reader, opts ...Option) (Model, error) {
+	return fromDelimited(r, ',', opts)
+}
+
+// FromTSV builds a table from tab-separated values, the first record being used as header.
+// Columns are ordered as set by WithColumnOrder, the remaining ones in order of appearance.
+// The column widths are inferred from the content.
+func FromTSV(r io.Reader, opts ...Option) (Model, error) {
+	return fromDelimited(r, '\t', opts)
+}
+
+// FromJSON builds a table from a JSON array of objects, each object being a row and each key
+// a column. Columns are ordered as set by WithColumnOrder, the remaining ones in order of
+// appearance. The column widths are inferred from the content.
+func FromJSON(r io.Reader, opts ...Option) (Model, error) {
+	options, err := parseOptions(opts)
+	if err != nil {
+		return Model{}, err
+	}
+
+	objects, keys, err := decodeObjects(r)
+	if err != nil {
+		return Model{}, err
+	}
+
+	order, err := columnOrder(keys, options.columnOrder)
+	if err != nil {
+		return Model{}, err
+	}
+
+	rows := make([]table.Row, 0, len(objects))
+	for _, object := range objects {
+		row := make(table.Row, 0, len(order))
+		for _, i := range order {
+			row = append(row, object[keys[i]])
+		}
+		rows = append(rows, row)
+	}
+
+	return newLoadedModel(options, orderedTitles(keys, order), rows)
+}
+
+// WithColumnOrder sets the order of the columns loaded by FromCSV, FromTSV and FromJSON. Every title
+// must be a loaded column.
+func WithColumnOrder(titles ...string) Option {
+	return func(options *options) error {
+		options.columnOrder = titles
+		return nil
+	}
+}
+
+func fromDelimited(r io.Reader, separator rune, opts []Option) (Model, error) {
+	options, err := parseOptions(opts)
+	if err != nil {
+		return Model{}, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = separator
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return Model{}, err
+	}
+	if len(records) == 0 {
+		return Model{}, errors.New("missing header row")
+	}
+
+	header := records[0]
+	order, err := columnOrder(header, options.columnOrder)
+	if err != nil {
+		return Model{}, err
+	}
+
+	rows := make([]table.Row, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(table.Row, 0, len(order))
+		for _, i := range order {
+			if i < len(record) {
+				row = append(row, record[i])
+			} else {
+				row = append(row, "")
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return newLoadedModel(options, orderedTitles(header, order), rows)
+}
+
+// columnOrder returns the indexes of the loaded titles in display order: the titles of the column
+// order first, then the others in order of appearance.
+func columnOrder(titles []string, order []string) ([]int, error) {
+	indexes := make([]int, 0, len(titles))
+	used := make([]bool, len(titles))
+	for _, title := range order {
+		i := indexOf(titles, title)
+		if i < 0 {
+			return nil, fmt.Errorf("unknown column %q in column order", title)
+		}
+		if !used[i] {
+			indexes = append(indexes, i)
+			used[i] = true
+		}
+	}
+	for i := range titles {
+		if !used[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes, nil
+}
+
+func orderedTitles(titles []string, order []int) []string {
+	ordered := make([]string, 0, len(order))
+	for _, i := range order {
+		ordered = append(ordered, titles[i])
+	}
+	return ordered
+}
+
+// newLoadedModel builds a table from loaded columns and rows. The options set the columns and rows
+// over the loaded ones.
+func newLoadedModel(options options, titles []string, rows []table.Row) (Model, error) {
+	if options.columns == nil {
+		options.columns = inferColumns(titles, rows)
+	}
+	if options.rows == nil {
+		options.rows = rows
+	}
+	return newModel(options)
+}
+
+// inferColumns sizes each column to its widest value, header included.
+func inferColumns(titles []string, rows []table.Row) []table.Column {
+	columns := make([]table.Column, 0, len(titles))
+	for i, title := range titles {
+		width := runewidth.StringWidth(title)
+		for _, row := range rows {
+			if i < len(row) {
+				width = max(width, runewidth.StringWidth(row[i]))
+			}
+		}
+		columns = append(columns, table.Column{Title: title, Width: max(1, min(width, maxInferredWidth))})
+	}
+	return columns
+}
+
+// decodeObjects decodes a JSON array of objects, returning the objects with their values as
+// strings and the keys in order of appearance.
+func decodeObjects(r io.Reader) ([]map[string]string, []string, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	if token, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	} else if token != json.Delim('[') {
+		return nil, nil, errors.New("expected a JSON array of objects")
+	}
+
+	var objects []map[string]string
+	var keys []string
+	for decoder.More() {
+		if token, err := decoder.Token(); err != nil {
+			return nil, nil, err
+		} else if token != json.Delim('{') {
+			return nil, nil, errors.New("expected a JSON array of objects")
+		}
+
+		object := make(map[string]string)
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, nil, err
+			}
+			k := token.(string)
+
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return nil, nil, err
+			}
+
+			if object[k], err = jsonValueString(value); err != nil {
+				return nil, nil, err
+			}
+			if indexOf(keys, k) < 0 {
+				keys = append(keys, k)
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, nil, err
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, keys, nil
+}
+
+// jsonValueString converts a JSON value to the text displayed in a cell: strings are unquoted,
+// null is empty and other values are kept as compact JSON.
+func jsonValueString(value json.RawMessage) (string, error) {
+	trimmed := bytes.TrimSpace(value)
+	switch {
+	case bytes.Equal(trimmed, []byte("null")):
+		return "", nil
+	case len(trimmed) > 0 && trimmed[0] == '"':
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	default:
+		var b bytes.Buffer
+		if err := json.Compact(&b, trimmed); err != nil {
+			return "", fmt.Errorf("invalid JSON value: %w", err)
+		}
+		return strings.TrimSpace(b.String()), nil
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
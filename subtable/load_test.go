@@ -0,0 +1,150 @@
+package subtable
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func titles(m Model) []string {
+	var titles []string
+	for _, column := range m.columns {
+		titles = append(titles, column.Title)
+	}
+	return titles
+}
+
+func TestFromDelimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		load   func(input string, opts ...Option) (Model, error)
+		input  string
+		opts   []Option
+		titles []string
+		rows   []table.Row
+	}{
+		{
+			name:   "csv",
+			load:   func(input string, opts ...Option) (Model, error) { return FromCSV(strings.NewReader(input), opts...) },
+			input:  "name,cpu\napi,\"0,5\"\nweb\n",
+			titles: []string{"name", "cpu"},
+			rows:   []table.Row{{"api", "0,5"}, {"web", ""}},
+		},
+		{
+			name:   "tsv",
+			load:   func(input string, opts ...Option) (Model, error) { return FromTSV(strings.NewReader(input), opts...) },
+			input:  "name\tcpu\napi\t0.5\n",
+			titles: []string{"name", "cpu"},
+			rows:   []table.Row{{"api", "0.5"}},
+		},
+		{
+			name:   "csv with column order",
+			load:   func(input string, opts ...Option) (Model, error) { return FromCSV(strings.NewReader(input), opts...) },
+			input:  "name,cpu,memory\napi,0.5,2048\n",
+			opts:   []Option{WithColumnOrder("memory", "name")},
+			titles: []string{"memory", "name", "cpu"},
+			rows:   []table.Row{{"2048", "api", "0.5"}},
+		},
+		{
+			name:   "tsv with column order",
+			load:   func(input string, opts ...Option) (Model, error) { return FromTSV(strings.NewReader(input), opts...) },
+			input:  "name\tcpu\napi\n",
+			opts:   []Option{WithColumnOrder("cpu")},
+			titles: []string{"cpu", "name"},
+			rows:   []table.Row{{"", "api"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := test.load(test.input, test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := titles(m); !reflect.DeepEqual(got, test.titles) {
+				t.Errorf("titles = %q, want %q", got, test.titles)
+			}
+			if !reflect.DeepEqual(m.rows, test.rows) {
+				t.Errorf("rows = %q, want %q", m.rows, test.rows)
+			}
+		})
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	input := `[{"name": "api", "cpu": 0.5, "labels": {"tier": "back"}}, {"name": "web", "ready": true, "cpu": null}]`
+
+	m, err := FromJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"name", "cpu", "labels", "ready"}; !reflect.DeepEqual(titles(m), want) {
+		t.Errorf("titles = %q, want %q", titles(m), want)
+	}
+	want := []table.Row{{"api", "0.5", `{"tier":"back"}`, ""}, {"web", "", "", "true"}}
+	if !reflect.DeepEqual(m.rows, want) {
+		t.Errorf("rows = %q, want %q", m.rows, want)
+	}
+
+	m, err = FromJSON(strings.NewReader(input), WithColumnOrder("ready", "name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ready", "name", "cpu", "labels"}; !reflect.DeepEqual(titles(m), want) {
+		t.Errorf("ordered titles = %q, want %q", titles(m), want)
+	}
+	if want := (table.Row{"", "api", "0.5", `{"tier":"back"}`}); !reflect.DeepEqual(m.rows[0], want) {
+		t.Errorf("ordered row = %q, want %q", m.rows[0], want)
+	}
+}
+
+func TestLoadedColumnsCanBeOverridden(t *testing.T) {
+	columns := []table.Column{{Title: "Name", Width: 10}}
+	m, err := FromCSV(strings.NewReader("name\napi\n"), WithColumns(columns))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m.columns, columns) {
+		t.Errorf("columns = %v, want %v", m.columns, columns)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		load    func() (Model, error)
+		problem string
+	}{
+		{"empty csv", func() (Model, error) { return FromCSV(strings.NewReader("")) }, "missing header row"},
+		{"unknown csv column", func() (Model, error) {
+			return FromCSV(strings.NewReader("name\napi\n"), WithColumnOrder("cpu"))
+		}, `unknown column "cpu"`},
+		{"unknown tsv column", func() (Model, error) {
+			return FromTSV(strings.NewReader("name\napi\n"), WithColumnOrder("cpu"))
+		}, `unknown column "cpu"`},
+		{"unknown json column", func() (Model, error) {
+			return FromJSON(strings.NewReader(`[{"name": "api"}]`), WithColumnOrder("cpu"))
+		}, `unknown column "cpu"`},
+		{"json object", func() (Model, error) { return FromJSON(strings.NewReader(`{"name": "api"}`)) }, "expected a JSON array of objects"},
+		{"json array of values", func() (Model, error) { return FromJSON(strings.NewReader(`[1, 2]`)) }, "expected a JSON array of objects"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.load()
+			if err == nil || !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("error = %v, want %q", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestInferColumns(t *testing.T) {
+	rows := []table.Row{{"a", strings.Repeat("x", 50)}, {"abcdef"}}
+	columns := inferColumns([]string{"id", ""}, rows)
+	want := []table.Column{{Title: "id", Width: 6}, {Title: "", Width: maxInferredWidth}}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %v, want %v", columns, want)
+	}
+}
@@ -0,0 +1,39 @@
+package subtable
+
+import (
+	"github.com/Funkit/theiere/mouse"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// updateMouse moves the cursor with the wheel, and selects a clicked row.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.MouseWheelUp:
+		m.Table.MoveUp(1)
+	case tea.MouseWheelDown:
+		m.Table.MoveDown(1)
+	case tea.MouseLeft:
+		row, ok := m.rowAt(msg)
+		if !ok {
+			return nil
+		}
+		m.Table.SetCursor(row)
+		m.syncRowOffset()
+		selected := m.selectMsg(row, m.rows[row])
+		return func() tea.Msg { return selected }
+	}
+	m.syncRowOffset()
+	return nil
+}
+
+// rowAt returns the index of the row drawn at the position of the event, inside the border and below the header.
+func (m *Model) rowAt(msg tea.MouseMsg) (int, bool) {
+	header := m.renderHeader(m.visibleColumns())
+	top := 1 + lipgloss.Height(header)
+	rows := min(m.Table.Height(), len(m.rows)-m.rowOffset)
+	if !mouse.In(msg, 1, top, lipgloss.Width(header), rows) {
+		return 0, false
+	}
+	return m.rowOffset + msg.Y - top, true
+}
@@ -0,0 +1,87 @@
+package subtable
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"strings"
+)
+
+// CellStyleFunc returns the style of a cell from its row and column indexes and its raw value.
+// The layout (width, alignment, padding) is handled by the table and should not be set.
+type CellStyleFunc func(row, column int, value string) lipgloss.Style
+
+// renderTable renders the header and the rows currently in the viewport.
+func (m *Model) renderTable() string {
+	visible := m.visibleColumns()
+
+	lines := []string{m.renderHeader(visible)}
+
+	end := min(m.rowOffset+m.Table.Height(), len(m.rows))
+	for row := m.rowOffset; row < end; row++ {
+		lines = append(lines, m.renderRow(row, visible))
+	}
+	for len(lines) < m.Table.Height()+1 {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderHeader(visible []int) string {
+	var header []string
+	for _, i := range visible {
+		col := m.columns[i]
+		box := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true).Align(m.alignments[i])
+		header = append(header, m.tableStyle.Header.Render(box.Render(runewidth.Truncate(col.Title, col.Width, "…"))))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Left, header...)
+}
+
+func (m *Model) renderRow(row int, visible []int) string {
+	cells := make([]string, 0, len(visible))
+	for _, i := range visible {
+		value := m.cellValue(row, i)
+
+		text := value
+		if format, ok := m.formatters[i]; ok {
+			text = format(value)
+		}
+		if i == 0 && m.treeRoots != nil {
+			text = m.treePrefix(row) + text
+		}
+
+		width := m.columns[i].Width
+		box := lipgloss.NewStyle().Width(width).MaxWidth(width).Inline(true).Align(m.alignments[i])
+
+		style := m.tableStyle.Cell.Copy()
+		if row == m.Table.Cursor() {
+			style = style.Inherit(m.tableStyle.Selected)
+		}
+		if m.cellStyle != nil {
+			style = style.Inherit(m.cellStyle(row, i, value))
+		}
+
+		cells = append(cells, style.Render(box.Render(runewidth.Truncate(text, width, "…"))))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Left, cells...)
+}
+
+func (m *Model) cellValue(row, column int) string {
+	if column < len(m.rows[row]) {
+		return m.rows[row][column]
+	}
+	return ""
+}
+
+// syncRowOffset scrolls the rendered rows so that the cursor stays visible.
+func (m *Model) syncRowOffset() {
+	cursor, height := m.Table.Cursor(), m.Table.Height()
+	if cursor < m.rowOffset {
+		m.rowOffset = cursor
+	} else if cursor >= m.rowOffset+height {
+		m.rowOffset = cursor - height + 1
+	}
+	m.rowOffset = max(0, min(m.rowOffset, len(m.rows)-height))
+}
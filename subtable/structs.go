@@ -0,0 +1,303 @@
+package subtable
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Funkit/theiere/subview"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StructModel is a table built from a slice of structs. Rows are generated from the exported
+// fields, configured with the `table` struct tag:
+//
+//	type Pod struct {
+//		Name     string        `table:"Name,width=20,order=1"`
+//		Memory   int64         `table:"Memory,format=bytes"`
+//		CPU      float64       `table:"CPU,format=%.2f"`
+//		Uptime   time.Duration `table:"Uptime,format=duration"`
+//		UID      string        `table:",hidden"`
+//		internal string
+//		Ignored  string        `table:"-"`
+//	}
+//
+// The first tag value is the column title, the field name being used when empty. The format is
+// either a fmt verb applied to the field value or one of "thousands", "duration" and "bytes".
+// Columns with an order come first, sorted, then the others in declaration order. The width
+// is inferred from the content when not set.
+type StructModel[T any] struct {
+	Model
+	items  []T
+	fields []structField
+}
+
+// ItemSelected is sent when a row is selected in a StructModel, with the struct it was built from.
+type ItemSelected[T any] struct {
+	Index int
+	Item  T
+}
+
+type structField struct {
+	index  int
+	title  string
+	width  int
+	format string
+	hidden bool
+	order  *int
+}
+
+// FromStructs builds a table from a slice of structs (or pointers to structs). Options are
+// applied after the generated columns and rows.
+func FromStructs[T any](items []T, opts ...Option) (*StructModel[T], error) {
+	fields, err := parseStructFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0, len(fields))
+	var hidden []int
+	generated := []Option{}
+	for i, field := range fields {
+		titles = append(titles, field.title)
+		if field.hidden {
+			hidden = append(hidden, i)
+		}
+		if formatter, ok := namedFormatters[field.format]; ok {
+			generated = append(generated, WithColumnFormatter(i, formatter))
+		}
+	}
+
+	rows := structRows(items, fields)
+	columns := inferColumns(titles, rows)
+	for i, field := range fields {
+		if field.width > 0 {
+			columns[i].Width = field.width
+		}
+	}
+
+	generated = append(generated, WithColumns(columns), WithRows(rows), WithHiddenColumns(hidden...))
+
+	base, err := New(append(generated, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &StructModel[T]{
+		Model:  base,
+		items:  items,
+		fields: fields,
+	}
+	m.selectMsg = func(line int, row table.Row) tea.Msg {
+		index := m.itemIndex(line)
+		if index < 0 {
+			return selected(line, row)
+		}
+		return ItemSelected[T]{Index: index, Item: m.items[index]}
+	}
+
+	return m, nil
+}
+
+// Items returns the structs displayed in the table.
+func (m *StructModel[T]) Items() []T {
+	return m.items
+}
+
+// ItemTreeRow returns a tree row displaying items[index], for the tree rows of a StructModel built
+// from the same items. Selecting the row sends the item, even when other items have the same values.
+func ItemTreeRow[T any](id string, items []T, index int) (*TreeRow, error) {
+	if index < 0 || index >= len(items) {
+		return nil, fmt.Errorf("item index %d out of range", index)
+	}
+	fields, err := parseStructFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return &TreeRow{ID: id, Row: structRows(items[index:index+1], fields)[0], item: index, isItem: true}, nil
+}
+
+// SetItems replaces the structs displayed in the table. With tree rows, the rows built by ItemTreeRow
+// are updated with the new values of their item.
+func (m *StructModel[T]) SetItems(items []T) {
+	m.items = items
+	rows := structRows(items, m.fields)
+	if m.treeRoots == nil {
+		m.rows = rows
+		m.rebuild()
+		return
+	}
+	updateItemRows(m.treeRoots, rows)
+	m.refreshTree(m.SelectedTreeRow())
+}
+
+func updateItemRows(treeRows []*TreeRow, rows []table.Row) {
+	for _, row := range treeRows {
+		if row.isItem && row.item < len(rows) {
+			row.Row = rows[row.item]
+		}
+		updateItemRows(row.Children, rows)
+	}
+}
+
+// SelectedItem returns the struct under the cursor, if any. With tree rows, only the rows built
+// by ItemTreeRow have an item.
+func (m *StructModel[T]) SelectedItem() (T, bool) {
+	var zero T
+	if len(m.rows) == 0 {
+		return zero, false
+	}
+	index := m.itemIndex(m.Table.Cursor())
+	if index < 0 {
+		return zero, false
+	}
+	return m.items[index], true
+}
+
+// itemIndex returns the index of the item displayed on a line, -1 when no item is displayed there.
+// Without tree rows, the lines are the items.
+func (m *StructModel[T]) itemIndex(line int) int {
+	index := line
+	if m.treeRoots != nil {
+		row := m.treeLines[line].row
+		if !row.isItem {
+			return -1
+		}
+		index = row.item
+	}
+	if index >= len(m.items) {
+		return -1
+	}
+	return index
+}
+
+func (m *StructModel[T]) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	_, cmd := m.Model.Update(msg)
+	return m, cmd
+}
+
+var namedFormatters = map[string]Formatter{
+	"thousands": FormatThousands,
+	"duration":  FormatDuration,
+	"bytes":     FormatBytes,
+}
+
+func parseStructFields(t reflect.Type) ([]structField, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct type, got %s", t)
+	}
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("table")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+
+		field := structField{index: i, title: f.Name}
+		parts := strings.Split(tag, ",")
+		if parts[0] != "" {
+			field.title = parts[0]
+		}
+
+		for _, part := range parts[1:] {
+			name, value, _ := strings.Cut(part, "=")
+			switch strings.TrimSpace(name) {
+			case "width":
+				width, err := strconv.Atoi(value)
+				if err != nil || width <= 0 {
+					return nil, fmt.Errorf("invalid width for field %s", f.Name)
+				}
+				field.width = width
+			case "order":
+				order, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid order for field %s", f.Name)
+				}
+				field.order = &order
+			case "format":
+				field.format = value
+			case "hidden":
+				field.hidden = true
+			case "":
+			default:
+				return nil, fmt.Errorf("unknown table tag option %q for field %s", name, f.Name)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, errors.New("no exported field to display")
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		switch {
+		case fields[i].order != nil && fields[j].order != nil:
+			return *fields[i].order < *fields[j].order
+		default:
+			return fields[i].order != nil && fields[j].order == nil
+		}
+	})
+
+	return fields, nil
+}
+
+func structRows[T any](items []T, fields []structField) []table.Row {
+	rows := make([]table.Row, 0, len(items))
+	for _, item := range items {
+		v := reflect.ValueOf(item)
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				break
+			}
+			v = v.Elem()
+		}
+
+		row := make(table.Row, 0, len(fields))
+		for _, field := range fields {
+			if v.Kind() != reflect.Struct {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, fieldString(v.Field(field.index), field.format))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// fieldString converts a field value to the raw value stored in the row.
+func fieldString(v reflect.Value, format string) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	value := v.Interface()
+	switch {
+	case strings.HasPrefix(format, "%"):
+		return fmt.Sprintf(format, value)
+	case format == "duration":
+		if d, ok := value.(time.Duration); ok {
+			return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		}
+	}
+
+	if t, ok := value.(time.Time); ok {
+		return t.Format("2006-01-02 15:04:05")
+	}
+
+	return fmt.Sprint(value)
+}
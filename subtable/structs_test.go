@@ -0,0 +1,125 @@
+package subtable
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type pod struct {
+	Name     string        `table:"Name,width=20,order=1"`
+	Memory   int64         `table:"Memory,format=bytes"`
+	CPU      float64       `table:"CPU,format=%.2f"`
+	Uptime   time.Duration `table:",format=duration"`
+	UID      string        `table:",hidden,order=0"`
+	internal string
+	Ignored  string `table:"-"`
+}
+
+func TestParseStructFields(t *testing.T) {
+	fields, err := parseStructFields(reflect.TypeOf(&pod{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zero, one := 0, 1
+	want := []structField{
+		{index: 4, title: "UID", hidden: true, order: &zero},
+		{index: 0, title: "Name", width: 20, order: &one},
+		{index: 1, title: "Memory", format: "bytes"},
+		{index: 2, title: "CPU", format: "%.2f"},
+		{index: 3, title: "Uptime", format: "duration"},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %+v, want %+v", fields, want)
+	}
+}
+
+func TestParseStructFieldsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  any
+	}{
+		{"not a struct", 0},
+		{"no exported field", struct{ name string }{}},
+		{"invalid width", struct {
+			Name string `table:"Name,width=0"`
+		}{}},
+		{"invalid order", struct {
+			Name string `table:"Name,order=first"`
+		}{}},
+		{"unknown option", struct {
+			Name string `table:"Name,bold"`
+		}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parseStructFields(reflect.TypeOf(test.typ)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestStructRows(t *testing.T) {
+	fields, err := parseStructFields(reflect.TypeOf(pod{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := structRows([]*pod{{Name: "api", Memory: 2048, CPU: 0.5, Uptime: 90 * time.Second, UID: "u1"}, nil}, fields)
+	want := []table.Row{{"u1", "api", "2048", "0.50", "90"}, {"", "", "", "", ""}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestSelectedItemWithTreeRows(t *testing.T) {
+	// Both pods are displayed with the same values.
+	items := []pod{{Name: "api"}, {Name: "api"}}
+	first, err := ItemTreeRow("first", items, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ItemTreeRow("second", items, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group := &TreeRow{ID: "group", Row: table.Row{"", "pods"}, Children: []*TreeRow{first, second}, Expanded: true}
+
+	m, err := FromStructs(items, WithTreeRows([]*TreeRow{group}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := m.SelectedItem(); ok {
+		t.Error("the group row has an item")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("no selection message")
+	}
+	if msg, ok := cmd().(ItemSelected[pod]); !ok || msg.Index != 1 {
+		t.Errorf("selection = %#v, want the second item", cmd())
+	}
+
+	m.SetItems([]pod{{Name: "api"}, {Name: "web"}})
+	if item, ok := m.SelectedItem(); !ok || item.Name != "web" {
+		t.Errorf("selected item = %v, %v, want web", item, ok)
+	}
+	if second.Row[1] != "web" {
+		t.Errorf("tree row = %q, want the new values", second.Row)
+	}
+}
+
+func TestItemTreeRowOutOfRange(t *testing.T) {
+	if _, err := ItemTreeRow("pod", []pod{{}}, 1); err == nil {
+		t.Error("expected an error")
+	}
+}
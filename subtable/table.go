@@ -2,39 +2,70 @@ package subtable
 
 import (
 	"errors"
+	"fmt"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
 	"github.com/charmbracelet/bubbles/help"
-	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"strings"
 )
 
-var (
-	baseStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240"))
-	helpStyle = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
-)
+// cellPadding is the horizontal padding added around each cell by the table styles.
+const cellPadding = 2
 
 type Model struct {
-	Table         table.Model
-	KeyMap        KeyMap
-	Help          help.Model
-	helpEnabled   bool
-	initCmd       func() tea.Cmd
-	columns       []table.Column
-	rows          []table.Row
-	tableStyle    table.Styles
-	height, width int
+	Table  table.Model
+	KeyMap KeyMap
+	// Deprecated: the help is shown by the frame help bar, from KeyHelp. Help is no longer rendered.
+	Help             help.Model
+	initCmd          func() tea.Cmd
+	columns          []table.Column
+	rows             []table.Row
+	tableStyle       table.Styles
+	customColor      bool
+	baseStyle        lipgloss.Style
+	indicatorStyle   lipgloss.Style
+	statusStyle      lipgloss.Style
+	height, width    int
+	horizontalScroll bool
+	frozenColumns    int
+	columnOffset     int
+	rowOffset        int
+	cellStyle        CellStyleFunc
+	alignments       map[int]lipgloss.Position
+	formatters       map[int]Formatter
+	exportInput      textinput.Model
+	exporting        bool
+	exportPath       string
+	status           string
+	hidden           map[int]bool
+	selectMsg        func(index int, row table.Row) tea.Msg
+	treeRoots        []*TreeRow
+	treeLines        []treeLine
+	childLoader      ChildLoader
 }
 
 type options struct {
-	columns     []table.Column
-	rows        []table.Row
-	width       *int
-	focusColor  *lipgloss.Color
-	helpEnabled bool
-	initCmd     func() tea.Cmd
-	keyMap      *KeyMap
+	columns          []table.Column
+	rows             []table.Row
+	width            *int
+	focusColor       *lipgloss.Color
+	initCmd          func() tea.Cmd
+	keyMap           *KeyMap
+	horizontalScroll bool
+	frozenColumns    int
+	cellStyle        CellStyleFunc
+	alignments       map[int]lipgloss.Position
+	formatters       map[int]Formatter
+	exportPath       *string
+	columnOrder      []string
+	hidden           map[int]bool
+	treeRoots        []*TreeRow
+	childLoader      ChildLoader
 }
 
 type Option func(options *options) error
@@ -70,9 +101,9 @@ func WithColor(color lipgloss.Color) Option {
 	}
 }
 
+// Deprecated: the bindings of the table are shown by the frame help bar. WithHelpDisplayed does nothing.
 func WithHelpDisplayed() Option {
 	return func(options *options) error {
-		options.helpEnabled = true
 		return nil
 	}
 }
@@ -84,6 +115,90 @@ func WithKeyMap(km KeyMap) Option {
 	}
 }
 
+// WithHorizontalScroll keeps the columns at their natural width instead of squashing
+// them into the table width. Columns that do not fit can be reached with the scroll keys.
+func WithHorizontalScroll() Option {
+	return func(options *options) error {
+		options.horizontalScroll = true
+		return nil
+	}
+}
+
+// WithFrozenColumns keeps the first n columns displayed when scrolling horizontally.
+func WithFrozenColumns(n int) Option {
+	return func(options *options) error {
+		if n < 0 {
+			return errors.New("invalid number of frozen columns")
+		}
+		options.frozenColumns = n
+		return nil
+	}
+}
+
+// WithCellStyle sets a function styling each cell from its position and raw value,
+// for instance to highlight failed statuses or to stripe rows.
+func WithCellStyle(cellStyle CellStyleFunc) Option {
+	return func(options *options) error {
+		options.cellStyle = cellStyle
+		return nil
+	}
+}
+
+// WithColumnAlignment sets the horizontal alignment of a column, header included.
+func WithColumnAlignment(column int, alignment lipgloss.Position) Option {
+	return func(options *options) error {
+		if column < 0 {
+			return errors.New("invalid column index")
+		}
+		if options.alignments == nil {
+			options.alignments = make(map[int]lipgloss.Position)
+		}
+		options.alignments[column] = alignment
+		return nil
+	}
+}
+
+// WithColumnFormatter sets the formatter used to display the values of a column.
+func WithColumnFormatter(column int, formatter Formatter) Option {
+	return func(options *options) error {
+		if column < 0 {
+			return errors.New("invalid column index")
+		}
+		if options.formatters == nil {
+			options.formatters = make(map[int]Formatter)
+		}
+		options.formatters[column] = formatter
+		return nil
+	}
+}
+
+// WithHiddenColumns hides columns from the view and from exports. Their values are kept in the rows.
+func WithHiddenColumns(columns ...int) Option {
+	return func(options *options) error {
+		if options.hidden == nil {
+			options.hidden = make(map[int]bool)
+		}
+		for _, column := range columns {
+			if column < 0 {
+				return errors.New("invalid column index")
+			}
+			options.hidden[column] = true
+		}
+		return nil
+	}
+}
+
+// WithExportPath sets the path suggested when exporting the table from the table view.
+func WithExportPath(path string) Option {
+	return func(options *options) error {
+		if path == "" {
+			return errors.New("invalid export path")
+		}
+		options.exportPath = &path
+		return nil
+	}
+}
+
 func WithInitCmd(initCmd func() tea.Cmd) Option {
 	return func(options *options) error {
 		options.initCmd = initCmd
@@ -92,15 +207,27 @@ func WithInitCmd(initCmd func() tea.Cmd) Option {
 }
 
 func New(opts ...Option) (Model, error) {
+	options, err := parseOptions(opts)
+	if err != nil {
+		return Model{}, err
+	}
+
+	return newModel(options)
+}
 
+func parseOptions(opts []Option) (options, error) {
 	var options options
 	for _, opt := range opts {
 		err := opt(&options)
 		if err != nil {
-			return Model{}, err
+			return options, err
 		}
 	}
+	return options, nil
+}
 
+// newModel builds a table from options already applied.
+func newModel(options options) (Model, error) {
 	width := 80
 	if options.width != nil {
 		width = *options.width
@@ -108,16 +235,13 @@ func New(opts ...Option) (Model, error) {
 
 	height := 20
 
-	adjustColumnWidth(options.columns, width)
+	if !options.horizontalScroll {
+		adjustColumnWidth(options.columns, width, options.hidden)
+	}
 
-	t := table.New(
-		table.WithColumns(options.columns),
-		table.WithRows(options.rows),
-		table.WithFocused(true),
-		table.WithHeight(height),
-	)
+	t := theme.Current()
 
-	selectColor := lipgloss.Color("212")
+	var selectColor lipgloss.TerminalColor = t.Selected
 	if options.focusColor != nil {
 		selectColor = *options.focusColor
 	}
@@ -127,7 +251,6 @@ func New(opts ...Option) (Model, error) {
 		Header:   lipgloss.NewStyle().Bold(true).Padding(0, 1),
 		Cell:     lipgloss.NewStyle().Padding(0, 1),
 	}
-	t.SetStyles(s)
 
 	var km KeyMap
 
@@ -137,20 +260,56 @@ func New(opts ...Option) (Model, error) {
 	} else {
 		km = *options.keyMap
 	}
-	t.KeyMap = km.asInternalTableMap()
 
-	return Model{
-		Table:       t,
-		KeyMap:      km,
-		Help:        help.New(),
-		helpEnabled: options.helpEnabled,
-		initCmd:     options.initCmd,
-		columns:     options.columns,
-		rows:        options.rows,
-		tableStyle:  s,
-		height:      height,
-		width:       width,
-	}, nil
+	exportPath := "export.csv"
+	if options.exportPath != nil {
+		exportPath = *options.exportPath
+	}
+
+	exportInput := textinput.New()
+	exportInput.Prompt = "Export to: "
+
+	m := Model{
+		KeyMap:           km,
+		Help:             help.New(),
+		initCmd:          options.initCmd,
+		columns:          options.columns,
+		rows:             options.rows,
+		tableStyle:       s,
+		customColor:      options.focusColor != nil,
+		height:           height,
+		width:            width,
+		horizontalScroll: options.horizontalScroll,
+		frozenColumns:    min(options.frozenColumns, len(options.columns)),
+		cellStyle:        options.cellStyle,
+		alignments:       options.alignments,
+		formatters:       options.formatters,
+		exportInput:      exportInput,
+		exportPath:       exportPath,
+		hidden:           options.hidden,
+		selectMsg:        selected,
+		treeRoots:        options.treeRoots,
+		childLoader:      options.childLoader,
+	}
+	m.setStyles(t)
+	m.columnOffset = m.frozenColumns
+	if m.treeRoots != nil {
+		m.refreshTree(nil)
+	} else {
+		m.rebuild()
+	}
+
+	return m, nil
+}
+
+// Selected is sent when a row is selected in the table.
+type Selected struct {
+	Index int
+	Row   table.Row
+}
+
+func selected(index int, row table.Row) tea.Msg {
+	return Selected{Index: index, Row: row}
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -161,75 +320,323 @@ func (m *Model) Init() tea.Cmd {
 }
 
 func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if m.exporting {
+		return m.updateExportPrompt(msg)
+	}
+
 	switch msg := msg.(type) {
+	case Exported:
+		if msg.Err != nil {
+			m.status = "Export failed: " + msg.Err.Error()
+		} else {
+			m.status = fmt.Sprintf("Exported %d rows to %s", msg.Rows, msg.Path)
+		}
+		return m, nil
+	case ChildrenLoaded:
+		if m.treeRoots != nil {
+			m.childrenLoaded(msg)
+		}
+		return m, nil
+	case tea.MouseMsg:
+		return m, m.updateMouse(msg)
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc":
+		m.status = ""
+		if key.Matches(msg, m.KeyMap.quitBinding()) {
 			return m, subview.GoUp
 		}
+		if m.treeRoots != nil {
+			if handled, cmd := m.updateTree(msg); handled {
+				return m, cmd
+			}
+		}
+		if key.Matches(msg, m.KeyMap.selectBinding()) && len(m.rows) > 0 {
+			index := m.Table.Cursor()
+			msg := m.selectMsg(index, m.rows[index])
+			return m, func() tea.Msg { return msg }
+		}
+		if key.Matches(msg, m.KeyMap.exportBinding()) {
+			m.exporting = true
+			m.exportInput.SetValue(m.exportPath)
+			m.exportInput.CursorEnd()
+			return m, m.exportInput.Focus()
+		}
+		if m.horizontalScroll {
+			left, right := m.KeyMap.scrollBindings()
+			switch {
+			case key.Matches(msg, left):
+				m.ScrollLeft()
+				return m, nil
+			case key.Matches(msg, right):
+				m.ScrollRight()
+				return m, nil
+			}
+		}
 	}
 	var cmd tea.Cmd
 	m.Table, cmd = m.Table.Update(msg)
+	m.syncRowOffset()
 	return m, cmd
 }
 
 func (m *Model) View() string {
-	if m.helpEnabled {
-		return lipgloss.JoinVertical(lipgloss.Left, baseStyle.Render(m.Table.View()), helpStyle.Render(m.Help.View(m.KeyMap)))
+	content := m.renderTable()
+	if m.horizontalScroll {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, m.scrollIndicators(lipgloss.Width(content)))
+	}
+
+	view := m.baseStyle.Render(content)
+	if m.exporting {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, m.exportInput.View())
+	} else if m.status != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, m.statusStyle.Render(m.status))
 	}
 
-	return baseStyle.Render(m.Table.View()) + "\n"
+	return view + "\n"
+}
+
+// updateExportPrompt handles the messages received while the export path is being typed.
+func (m *Model) updateExportPrompt(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.exporting = false
+			m.exportInput.Blur()
+			path := strings.TrimSpace(m.exportInput.Value())
+			if path == "" {
+				return m, nil
+			}
+			m.exportPath = path
+			return m, m.exportCmd(path)
+		case tea.KeyEsc:
+			m.exporting = false
+			m.exportInput.Blur()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
 }
 
 func (m *Model) SetHeight(height int) {
 	m.height = height - 4
-	//m.height = 25
 
-	adjustColumnWidth(m.columns, m.width)
+	if !m.horizontalScroll {
+		adjustColumnWidth(m.columns, m.width, m.hidden)
+	}
+	m.rebuild()
+}
 
-	t := table.New(
-		table.WithColumns(m.columns),
-		table.WithRows(m.rows),
-		table.WithFocused(true),
-		table.WithHeight(m.height),
-	)
+func (m *Model) SetWidth(width int) {
+	m.width = width - 10
 
-	t.SetStyles(m.tableStyle)
+	if !m.horizontalScroll {
+		adjustColumnWidth(m.columns, m.width, m.hidden)
+	}
+	m.rebuild()
+}
 
-	m.Table = t
+// ScrollLeft reveals the column hidden on the left of the scrollable columns, if any.
+func (m *Model) ScrollLeft() {
+	if left, _ := m.hiddenColumns(); left > 0 {
+		m.columnOffset--
+		for m.columnOffset > m.frozenColumns && m.hidden[m.columnOffset] {
+			m.columnOffset--
+		}
+		m.rebuild()
+	}
 }
 
-func (m *Model) SetWidth(width int) {
-	m.width = width - 10
-	//m.width = 90
+// ScrollRight reveals the next column hidden on the right of the table, if any.
+func (m *Model) ScrollRight() {
+	if _, right := m.hiddenColumns(); right > 0 {
+		m.columnOffset++
+		for m.columnOffset < len(m.columns)-1 && m.hidden[m.columnOffset] {
+			m.columnOffset++
+		}
+		m.rebuild()
+	}
+}
+
+func (m *Model) Reset() {
+	m.Table.SetCursor(0)
+	m.rowOffset = 0
+	m.exporting = false
+	m.exportInput.Blur()
+	m.status = ""
+	if m.columnOffset != m.frozenColumns {
+		m.columnOffset = m.frozenColumns
+		m.rebuild()
+	}
+}
 
-	adjustColumnWidth(m.columns, m.width)
+// rebuild recreates the internal table from the visible columns, keeping the cursor position.
+func (m *Model) rebuild() {
+	cursor := m.Table.Cursor()
+
+	visible := m.visibleColumns()
+	cols := make([]table.Column, 0, len(visible))
+	for _, i := range visible {
+		cols = append(cols, m.columns[i])
+	}
+
+	rows := make([]table.Row, 0, len(m.rows))
+	for _, r := range m.rows {
+		row := make(table.Row, 0, len(visible))
+		for _, i := range visible {
+			if i < len(r) {
+				row = append(row, r[i])
+			} else {
+				row = append(row, "")
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	height := m.height
+	if m.horizontalScroll {
+		height--
+	}
 
 	t := table.New(
-		table.WithColumns(m.columns),
-		table.WithRows(m.rows),
+		table.WithColumns(cols),
+		table.WithRows(rows),
 		table.WithFocused(true),
-		table.WithHeight(m.height),
+		table.WithHeight(height),
 	)
-
 	t.SetStyles(m.tableStyle)
+	t.KeyMap = m.KeyMap.asInternalTableMap()
+	t.MoveDown(cursor)
 
 	m.Table = t
+	m.syncRowOffset()
 }
 
-func (m *Model) Reset() {
-	m.Table.SetCursor(0)
+// visibleColumns returns the indexes of the columns to display: every column that is not hidden
+// when horizontal scrolling is disabled, otherwise the frozen columns followed by as many
+// scrollable columns as the width allows, starting at the scroll offset.
+func (m *Model) visibleColumns() []int {
+	var visible []int
+	if !m.horizontalScroll {
+		for i := range m.columns {
+			if !m.hidden[i] {
+				visible = append(visible, i)
+			}
+		}
+		return visible
+	}
+
+	used := 0
+	for i := 0; i < m.frozenColumns; i++ {
+		if !m.hidden[i] {
+			visible = append(visible, i)
+			used += m.columns[i].Width + cellPadding
+		}
+	}
+
+	scrolled := 0
+	for i := m.columnOffset; i < len(m.columns); i++ {
+		if m.hidden[i] {
+			continue
+		}
+		w := m.columns[i].Width + cellPadding
+		if used+w > m.width && scrolled > 0 {
+			break
+		}
+		visible = append(visible, i)
+		used += w
+		scrolled++
+	}
+
+	return visible
+}
+
+// hiddenColumns returns the number of scrollable columns hidden on each side of the table.
+func (m *Model) hiddenColumns() (left, right int) {
+	if !m.horizontalScroll {
+		return 0, 0
+	}
+	visible := m.visibleColumns()
+	last := m.frozenColumns - 1
+	if len(visible) > 0 {
+		last = visible[len(visible)-1]
+	}
+
+	for i := m.frozenColumns; i < len(m.columns); i++ {
+		if m.hidden[i] {
+			continue
+		}
+		if i < m.columnOffset {
+			left++
+		} else if i > last {
+			right++
+		}
+	}
+
+	return left, right
+}
+
+func (m *Model) scrollIndicators(width int) string {
+	left, right := m.hiddenColumns()
+
+	leftIndicator := ""
+	if left > 0 {
+		leftIndicator = fmt.Sprintf("‹ %d", left)
+	}
+	rightIndicator := ""
+	if right > 0 {
+		rightIndicator = fmt.Sprintf("%d ›", right)
+	}
+
+	gap := max(1, width-lipgloss.Width(leftIndicator)-lipgloss.Width(rightIndicator))
+
+	return m.indicatorStyle.Render(leftIndicator + strings.Repeat(" ", gap) + rightIndicator)
 }
 
-func adjustColumnWidth(col []table.Column, maxWidth int) {
+func adjustColumnWidth(col []table.Column, maxWidth int, hidden map[int]bool) {
 	sumWidth := 0
 	for i := 0; i < len(col); i++ {
-		sumWidth += col[i].Width
+		if !hidden[i] {
+			sumWidth += col[i].Width
+		}
 	}
 
 	for i := 0; i < len(col); i++ {
+		if hidden[i] {
+			continue
+		}
 		var f float64
 		f = float64(col[i].Width) / float64(sumWidth) * float64(maxWidth)
 		col[i].Width = int(f)
 	}
 }
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (m *Model) setStyles(t theme.Theme) {
+	m.baseStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(t.Muted)
+	m.indicatorStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	m.statusStyle = lipgloss.NewStyle().Foreground(t.Muted).PaddingLeft(1)
+}
+
+// SetTheme applies a theme to the table, keeping the selection color set with WithColor.
+func (m *Model) SetTheme(t theme.Theme) {
+	m.setStyles(t)
+	if !m.customColor {
+		m.tableStyle.Selected = m.tableStyle.Selected.Copy().Foreground(t.Selected)
+		m.Table.SetStyles(m.tableStyle)
+	}
+}
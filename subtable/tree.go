@@ -0,0 +1,201 @@
+package subtable
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+// TreeRow is a row of a tree table. Its children are displayed below it, indented, when it is expanded.
+type TreeRow struct {
+	ID       string
+	Row      table.Row
+	Children []*TreeRow
+	// HasChildren marks a row whose children are not known yet. They are requested
+	// from the child loader the first time the row is expanded.
+	HasChildren bool
+	Expanded    bool
+	loading     bool
+	// item is the index of the StructModel item the row displays, when isItem is set.
+	item   int
+	isItem bool
+}
+
+// ChildrenLoaded must be returned by the command of the child loader once the children of a row are available.
+type ChildrenLoaded struct {
+	ID       string
+	Children []*TreeRow
+	Err      error
+}
+
+// ChildLoader returns the command loading the children of a row, which must result in a ChildrenLoaded message.
+type ChildLoader func(row *TreeRow) tea.Cmd
+
+type treeLine struct {
+	row   *TreeRow
+	depth int
+}
+
+// WithTreeRows displays nested rows instead of the rows set by WithRows.
+// The expand markers and the indentation are displayed in the first column.
+func WithTreeRows(roots []*TreeRow) Option {
+	return func(options *options) error {
+		options.treeRoots = roots
+		return nil
+	}
+}
+
+// WithChildLoader sets the function loading the children of the rows marked with HasChildren.
+func WithChildLoader(loader ChildLoader) Option {
+	return func(options *options) error {
+		options.childLoader = loader
+		return nil
+	}
+}
+
+// SelectedTreeRow returns the tree row under the cursor, if any.
+func (m *Model) SelectedTreeRow() *TreeRow {
+	if len(m.treeLines) == 0 {
+		return nil
+	}
+	return m.treeLines[m.Table.Cursor()].row
+}
+
+func (r *TreeRow) expandable() bool {
+	return len(r.Children) > 0 || r.HasChildren
+}
+
+// updateTree handles the tree navigation keys, returning false when the key is not related to the tree.
+func (m *Model) updateTree(msg tea.KeyMsg) (bool, tea.Cmd) {
+	expandKey, collapseKey := m.KeyMap.treeBindings(m.horizontalScroll)
+	row := m.SelectedTreeRow()
+	if row == nil {
+		return false, nil
+	}
+
+	switch {
+	case key.Matches(msg, expandKey):
+		if row.expandable() && !row.Expanded {
+			return true, m.expand(row)
+		}
+		return true, nil
+	case key.Matches(msg, collapseKey):
+		if row.Expanded {
+			row.Expanded = false
+			m.refreshTree(row)
+			return true, nil
+		}
+		if parent := m.parentLine(m.Table.Cursor()); parent >= 0 {
+			m.Table.MoveUp(m.Table.Cursor() - parent)
+			m.syncRowOffset()
+		}
+		return true, nil
+	case key.Matches(msg, m.KeyMap.selectBinding()) && row.expandable():
+		if row.Expanded {
+			row.Expanded = false
+			m.refreshTree(row)
+			return true, nil
+		}
+		return true, m.expand(row)
+	}
+
+	return false, nil
+}
+
+func (m *Model) expand(row *TreeRow) tea.Cmd {
+	row.Expanded = true
+	var cmd tea.Cmd
+	if len(row.Children) == 0 && row.HasChildren && m.childLoader != nil && !row.loading {
+		row.loading = true
+		cmd = m.childLoader(row)
+	}
+	m.refreshTree(row)
+	return cmd
+}
+
+// childrenLoaded attaches the loaded children to their parent row.
+func (m *Model) childrenLoaded(msg ChildrenLoaded) {
+	row := findTreeRow(m.treeRoots, msg.ID)
+	if row == nil {
+		return
+	}
+	row.loading = false
+	if msg.Err != nil {
+		row.Expanded = false
+		m.status = "Loading failed: " + msg.Err.Error()
+	} else {
+		row.Children = msg.Children
+		row.HasChildren = len(msg.Children) > 0
+	}
+	m.refreshTree(m.SelectedTreeRow())
+}
+
+// refreshTree flattens the expanded rows and keeps the cursor on the given row.
+func (m *Model) refreshTree(selected *TreeRow) {
+	m.treeLines = m.treeLines[:0]
+	flattenTree(m.treeRoots, 0, &m.treeLines)
+
+	m.rows = make([]table.Row, 0, len(m.treeLines))
+	cursor := 0
+	for i, line := range m.treeLines {
+		m.rows = append(m.rows, line.row.Row)
+		if line.row == selected {
+			cursor = i
+		}
+	}
+
+	m.Table.SetCursor(0)
+	m.rebuild()
+	m.Table.MoveDown(cursor)
+	m.syncRowOffset()
+}
+
+// parentLine returns the index of the line of the parent of the given line, -1 for roots.
+func (m *Model) parentLine(index int) int {
+	depth := m.treeLines[index].depth
+	for i := index - 1; i >= 0; i-- {
+		if m.treeLines[i].depth < depth {
+			return i
+		}
+	}
+	return -1
+}
+
+// treePrefix returns the indentation and expand marker displayed before the first cell of a line.
+func (m *Model) treePrefix(index int) string {
+	line := m.treeLines[index]
+
+	marker := " "
+	switch {
+	case line.row.loading:
+		marker = "…"
+	case line.row.expandable() && line.row.Expanded:
+		marker = "▾"
+	case line.row.expandable():
+		marker = "▸"
+	}
+
+	return strings.Repeat("  ", line.depth) + marker + " "
+}
+
+func flattenTree(rows []*TreeRow, depth int, lines *[]treeLine) {
+	for _, row := range rows {
+		*lines = append(*lines, treeLine{row: row, depth: depth})
+		if row.Expanded {
+			flattenTree(row.Children, depth+1, lines)
+		}
+	}
+}
+
+func findTreeRow(rows []*TreeRow, id string) *TreeRow {
+	for _, row := range rows {
+		if row.ID == id {
+			return row
+		}
+		if found := findTreeRow(row.Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -15,6 +15,14 @@ type TreeUp struct {
 	bool
 }
 
+// Upward is implemented by the messages a component sends to the containers above it, like TreeUp.
+// Containers routing the messages of their content to it, like tabs, let them through unchanged.
+type Upward interface {
+	Upward()
+}
+
+func (TreeUp) Upward() {}
+
 func GoUp() tea.Msg {
 	return TreeUp{}
 }
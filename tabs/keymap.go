@@ -1,20 +1,45 @@
 package tabs
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines keybindings. It satisfies to the help.KeyMap interface
 type KeyMap struct {
-	// Keybindings used when browsing tabs.
+	// Keybindings used when browsing tabs. Up and Down only apply to tabs placed on the left or right.
 	NextPage key.Binding
 	PrevPage key.Binding
+	Up       key.Binding
+	Down     key.Binding
+
+	// Keybindings managing tabs. Close only applies to closeable tabs, and New to tabs built with WithNewTab.
+	Close     key.Binding
+	New       key.Binding
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+
+	// Keybindings of the list of tabs, which opens when the tabs overflow the header.
+	Picker       key.Binding
+	PickerUp     key.Binding
+	PickerDown   key.Binding
+	PickerSelect key.Binding
+
+	// Keybindings answering the confirmation asked before closing a tab with unsaved changes.
+	Confirm key.Binding
+	Cancel  key.Binding
 
 	// The quit keybinding. This won't be caught when filtering.
 	Quit key.Binding
 }
 
-// DefaultKeyMap returns a default set of keybindings.
+func init() {
+	keys.Define("tabs", KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
 func DefaultKeyMap() KeyMap {
-	return KeyMap{
+	return keys.Apply("tabs", KeyMap{
 		PrevPage: key.NewBinding(
 			key.WithKeys("shift+tab"),
 			key.WithHelp("shift+tab", "prev tab"),
@@ -23,26 +48,102 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "next tab"),
 		),
+		// The arrows alone are left to the content of the tabs.
+		Up: key.NewBinding(
+			key.WithKeys("ctrl+up"),
+			key.WithHelp("ctrl+↑", "prev tab"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("ctrl+down"),
+			key.WithHelp("ctrl+↓", "next tab"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "close tab"),
+		),
+		New: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "new tab"),
+		),
+		MoveLeft: key.NewBinding(
+			key.WithKeys("ctrl+left"),
+			key.WithHelp("ctrl+←", "move tab left"),
+		),
+		MoveRight: key.NewBinding(
+			key.WithKeys("ctrl+right"),
+			key.WithHelp("ctrl+→", "move tab right"),
+		),
+		Picker: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "all tabs"),
+		),
+		PickerUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		PickerDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		PickerSelect: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "show tab"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "close"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "keep"),
+		),
 		// Quitting.
 		Quit: key.NewBinding(
 			key.WithKeys("q", "esc"),
 			key.WithHelp("q", "quit"),
 		),
-	}
+	})
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		k.NextPage,
 		k.PrevPage,
+		k.Up,
+		k.Down,
+		k.Close,
+		k.New,
+		k.Picker,
 		k.Quit,
 	}
 }
 
 func (k KeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{
-		k.NextPage,
-		k.PrevPage,
-		k.Quit,
-	}}
+	return [][]key.Binding{
+		{
+			k.NextPage,
+			k.PrevPage,
+			k.Up,
+			k.Down,
+		},
+		{
+			k.Close,
+			k.New,
+			k.MoveLeft,
+			k.MoveRight,
+		},
+		{
+			k.Picker,
+			k.PickerUp,
+			k.PickerDown,
+			k.PickerSelect,
+		},
+		{
+			k.Confirm,
+			k.Cancel,
+		},
+		{
+			k.Quit,
+		},
+	}
 }
@@ -0,0 +1,138 @@
+package tabs
+
+import (
+	"github.com/Funkit/theiere/subview"
+	tea "github.com/charmbracelet/bubbletea"
+	"reflect"
+)
+
+// TabMsg sends a message to the content of the tab with the given ID, even when it is not the active tab.
+// Tabs nested in the contents are searched when no tab has this ID.
+type TabMsg struct {
+	Tab string
+	Msg tea.Msg
+}
+
+func (TabMsg) Upward() {}
+
+// teaPackage is the package of the bubbletea messages, which control the program rather than a component.
+var teaPackage = reflect.TypeOf(tea.BatchMsg{}).PkgPath()
+
+// Route returns a command whose messages are sent to the tab with the given ID, with a TabMsg. The tabs route the
+// commands of their contents, so that their results reach them even once another tab is shown.
+// The bubbletea messages, like quitting, and the subview.Upward messages, like going up, are left unchanged.
+// The commands of batches and sequences are routed one by one.
+func Route(tab string, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return route(tab, cmd())
+	}
+}
+
+func route(tab string, msg tea.Msg) tea.Msg {
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case subview.Upward:
+		return msg
+	case tea.BatchMsg:
+		return tea.BatchMsg(routeAll(tab, msg))
+	}
+
+	t := reflect.TypeOf(msg)
+	if t.PkgPath() != teaPackage {
+		return TabMsg{Tab: tab, Msg: msg}
+	}
+	// The message of tea.Sequence is not exported: it is rebuilt from its commands.
+	if t.Kind() == reflect.Slice && t.Elem() == reflect.TypeOf(tea.Cmd(nil)) {
+		value := reflect.ValueOf(msg)
+		cmds := make([]tea.Cmd, value.Len())
+		for i := range cmds {
+			cmds[i] = value.Index(i).Interface().(tea.Cmd)
+		}
+		return tea.Sequence(routeAll(tab, cmds)...)()
+	}
+	return msg
+}
+
+func routeAll(tab string, cmds []tea.Cmd) []tea.Cmd {
+	var routed []tea.Cmd
+	for _, cmd := range cmds {
+		if cmd != nil {
+			routed = append(routed, Route(tab, cmd))
+		}
+	}
+	return routed
+}
+
+// WithResetOnSwitch resets the content of a tab when another tab is shown, and initializes it again when it is shown
+// back. By default, the tabs keep their state.
+func WithResetOnSwitch() Option {
+	return func(options *options) error {
+		options.resetOnSwitch = true
+
+		return nil
+	}
+}
+
+// updateTabMsg passes a message to the tab with its ID, in these tabs or in nested ones. The tab messages, like
+// StatusMsg, are applied by the tabs instead.
+func (m *Model) updateTabMsg(msg TabMsg) (bool, tea.Cmd) {
+	index := m.tabIndex(msg.Tab)
+	if index < 0 {
+		for _, content := range m.TabContents {
+			if sub, ok := content.(*Model); ok {
+				if handled, cmd := sub.updateTabMsg(msg); handled {
+					return true, cmd
+				}
+			}
+		}
+		return false, nil
+	}
+
+	if handled, cmd := m.updateTabs(msg.Msg); handled {
+		return true, cmd
+	}
+
+	var cmd tea.Cmd
+	m.TabContents[index], cmd = m.TabContents[index].Update(msg.Msg)
+	return true, Route(msg.Tab, cmd)
+}
+
+// sync initializes the active tab when it is first shown. Unless the tabs keep their state, the tab shown before is
+// reset, to be initialized again when shown back.
+func (m *Model) sync() tea.Cmd {
+	if len(m.TabContents) == 0 {
+		m.shown = -1
+		return nil
+	}
+	if m.ActiveTab == m.shown {
+		return nil
+	}
+
+	if m.resetOnSwitch && m.shown >= 0 {
+		m.TabContents[m.shown].Reset()
+		m.initialized[m.shown] = false
+	}
+	m.shown = m.ActiveTab
+	if m.initialized[m.shown] {
+		return nil
+	}
+	m.initialized[m.shown] = true
+	return Route(m.ids[m.shown], m.TabContents[m.shown].Init())
+}
+
+// moved returns the new position of the tab at index once the tab at from is moved to to.
+func moved(index, from, to int) int {
+	switch {
+	case index == from:
+		return to
+	case from < index && to >= index:
+		return index - 1
+	case from > index && to <= index:
+		return index + 1
+	}
+	return index
+}
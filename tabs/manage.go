@@ -0,0 +1,332 @@
+package tabs
+
+import (
+	"fmt"
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AddTabMsg adds a tab at the end, or at Index if it is set. The tab is shown if Activate is set.
+// Like SelectTabMsg, it targets the tabs with the ID Tabs, nested tabs being searched when the ID differs.
+type AddTabMsg struct {
+	Tabs     string
+	Tab      Tab
+	Index    *int
+	Activate bool
+}
+
+func (AddTabMsg) Upward() {}
+
+// RemoveTabMsg removes the tab with the ID Tab, or the tab at Index when Tab is empty, without asking for
+// confirmation. It targets the tabs with the ID Tabs.
+type RemoveTabMsg struct {
+	Tabs  string
+	Tab   string
+	Index int
+}
+
+func (RemoveTabMsg) Upward() {}
+
+// MoveTabMsg moves the tab at From to the position To, in the tabs with the ID Tabs.
+type MoveTabMsg struct {
+	Tabs string
+	From int
+	To   int
+}
+
+func (MoveTabMsg) Upward() {}
+
+// Dirtier is implemented by tab contents holding unsaved changes. Closing a dirty tab with the close key
+// asks for confirmation first.
+type Dirtier interface {
+	Dirty() bool
+}
+
+// AddTab adds a tab after the others. Its content is initialized when first shown.
+func (m *Model) AddTab(tab Tab) (tea.Cmd, error) {
+	return m.InsertTab(len(m.Tabs), tab)
+}
+
+// InsertTab adds a tab at the given position. Its content is initialized when first shown, by the returned command if
+// it is the only tab.
+func (m *Model) InsertTab(index int, tab Tab) (tea.Cmd, error) {
+	if tab.Content == nil {
+		return nil, fmt.Errorf("missing content for tab %q", tab.Name)
+	}
+	if index < 0 || index > len(m.Tabs) {
+		return nil, fmt.Errorf("invalid index %d for tab %q", index, tab.Name)
+	}
+
+	m.Tabs = insert(m.Tabs, index, tab.Name)
+	m.TabContents = insert(m.TabContents, index, tab.Content)
+	m.closeable = insert(m.closeable, index, tab.Closeable)
+	m.ids = insert(m.ids, index, tabID(tab))
+	m.statuses = insert(m.statuses, index, tab.Status)
+	m.initialized = insert(m.initialized, index, false)
+	if index <= m.ActiveTab && len(m.Tabs) > 1 {
+		m.ActiveTab++
+	}
+	if index <= m.shown {
+		m.shown++
+	}
+
+	// The sidebar may widen with the new title.
+	m.resize()
+	theme.Apply(tab.Content, theme.Current())
+
+	return tea.Batch(m.sync(), m.spin()), nil
+}
+
+// RemoveTab removes a tab and its content. The next tab is shown if it was the active one.
+func (m *Model) RemoveTab(index int) error {
+	if index < 0 || index >= len(m.Tabs) {
+		return fmt.Errorf("invalid tab index %d", index)
+	}
+
+	m.Tabs = remove(m.Tabs, index)
+	m.TabContents = remove(m.TabContents, index)
+	m.closeable = remove(m.closeable, index)
+	m.ids = remove(m.ids, index)
+	m.statuses = remove(m.statuses, index)
+	m.initialized = remove(m.initialized, index)
+	switch {
+	case index == m.shown:
+		// The removed tab is not reset: the next one shown is only initialized.
+		m.shown = -1
+	case index < m.shown:
+		m.shown--
+	}
+	if index < m.ActiveTab || m.ActiveTab == len(m.Tabs) {
+		m.ActiveTab = max(m.ActiveTab-1, 0)
+	}
+	m.closing = false
+	m.picking = false
+
+	return nil
+}
+
+// MoveTab moves a tab to a new position, the active tab staying the same.
+func (m *Model) MoveTab(from, to int) error {
+	if from < 0 || from >= len(m.Tabs) {
+		return fmt.Errorf("invalid tab index %d", from)
+	}
+	if to < 0 || to >= len(m.Tabs) {
+		return fmt.Errorf("invalid index %d for tab %q", to, m.Tabs[from])
+	}
+
+	name, content, closeable := m.Tabs[from], m.TabContents[from], m.closeable[from]
+	id, status, initialized := m.ids[from], m.statuses[from], m.initialized[from]
+	m.Tabs = insert(remove(m.Tabs, from), to, name)
+	m.TabContents = insert(remove(m.TabContents, from), to, content)
+	m.closeable = insert(remove(m.closeable, from), to, closeable)
+	m.ids = insert(remove(m.ids, from), to, id)
+	m.statuses = insert(remove(m.statuses, from), to, status)
+	m.initialized = insert(remove(m.initialized, from), to, initialized)
+
+	m.ActiveTab = moved(m.ActiveTab, from, to)
+	if m.shown >= 0 {
+		m.shown = moved(m.shown, from, to)
+	}
+
+	return nil
+}
+
+// tabID returns the ID targeting a tab with a StatusMsg.
+func tabID(tab Tab) string {
+	if tab.ID != "" {
+		return tab.ID
+	}
+	return tab.Name
+}
+
+// updateTabs applies the tab messages to these tabs or to nested ones, depending on their tabs ID. Errors are
+// displayed below the targeted tabs.
+func (m *Model) updateTabs(msg tea.Msg) (bool, tea.Cmd) {
+	switch msg := msg.(type) {
+	case AddTabMsg:
+		target := m.target(msg.Tabs)
+		if target == nil {
+			return false, nil
+		}
+		index := len(target.Tabs)
+		if msg.Index != nil {
+			index = *msg.Index
+		}
+		cmd, err := target.InsertTab(index, msg.Tab)
+		target.err = err
+		if err == nil && msg.Activate {
+			target.ActiveTab = index
+		}
+		return true, cmd
+	case RemoveTabMsg:
+		target := m.target(msg.Tabs)
+		if target == nil {
+			return false, nil
+		}
+		index := msg.Index
+		if msg.Tab != "" {
+			index = target.tabIndex(msg.Tab)
+			if index < 0 {
+				target.err = fmt.Errorf("unknown tab %q", msg.Tab)
+				return true, nil
+			}
+		}
+		target.err = target.RemoveTab(index)
+		return true, nil
+	case MoveTabMsg:
+		target := m.target(msg.Tabs)
+		if target == nil {
+			return false, nil
+		}
+		target.err = target.MoveTab(msg.From, msg.To)
+		return true, nil
+	case SelectTabMsg:
+		target := m.target(msg.Tabs)
+		if target == nil {
+			return false, nil
+		}
+		if msg.Index >= 0 && msg.Index < len(target.Tabs) {
+			target.ActiveTab = msg.Index
+		}
+		return true, nil
+	case StatusMsg:
+		return m.updateStatus(msg)
+	case TabMsg:
+		return m.updateTabMsg(msg)
+	case spinner.TickMsg:
+		if msg.ID == m.spinner.ID() {
+			return true, m.updateSpinner(msg)
+		}
+	}
+	return false, nil
+}
+
+// target returns these tabs if they have the given ID, otherwise the nested tabs with this ID, nil if there are none.
+func (m *Model) target(id string) *Model {
+	if id == m.id {
+		return m
+	}
+	for _, content := range m.TabContents {
+		if sub, ok := content.(*Model); ok {
+			if target := sub.target(id); target != nil {
+				return target
+			}
+		}
+	}
+	return nil
+}
+
+// requestClose closes the active tab, after confirmation if its content has unsaved changes.
+func (m *Model) requestClose() tea.Cmd {
+	if dirtier, ok := m.TabContents[m.ActiveTab].(Dirtier); ok && dirtier.Dirty() {
+		m.closing = true
+		return nil
+	}
+	m.err = m.RemoveTab(m.ActiveTab)
+	return nil
+}
+
+// updateClosing answers the confirmation asked before closing the active tab.
+func (m *Model) updateClosing(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.KeyMap.Confirm):
+		m.err = m.RemoveTab(m.ActiveTab)
+	case key.Matches(msg, m.KeyMap.Cancel):
+		m.closing = false
+	}
+	return nil
+}
+
+// updateEmpty handles the keys once every tab is closed: only opening a new tab and quitting remain.
+func (m *Model) updateEmpty(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.KeyMap.New) && m.factory != nil:
+		return m.newTab()
+	case key.Matches(msg, m.KeyMap.Quit):
+		return subview.GoUp
+	}
+	return nil
+}
+
+// newTab adds a tab built by the factory after the others, and shows it.
+func (m *Model) newTab() tea.Cmd {
+	tab, err := m.factory()
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	cmd, err := m.AddTab(tab)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	m.ActiveTab = len(m.Tabs) - 1
+	return cmd
+}
+
+// keyMap returns the key bindings, disabling the ones that do not apply to the current tabs.
+func (m *Model) keyMap() KeyMap {
+	k := m.KeyMap
+	active := len(m.Tabs) > 0
+	k.NextPage.SetEnabled(len(m.Tabs) > 1)
+	k.PrevPage.SetEnabled(len(m.Tabs) > 1)
+	k.Up.SetEnabled(m.vertical() && len(m.Tabs) > 1)
+	k.Down.SetEnabled(m.vertical() && len(m.Tabs) > 1)
+	k.MoveLeft.SetEnabled(len(m.Tabs) > 1)
+	k.MoveRight.SetEnabled(len(m.Tabs) > 1)
+	k.Close.SetEnabled(active && m.closeable[m.ActiveTab])
+	k.New.SetEnabled(m.factory != nil)
+	k.Confirm.SetEnabled(m.closing)
+	k.Cancel.SetEnabled(m.closing)
+	k.Picker.SetEnabled(m.picking || (active && m.overflows()))
+	k.PickerUp.SetEnabled(m.picking)
+	k.PickerDown.SetEnabled(m.picking)
+	k.PickerSelect.SetEnabled(m.picking)
+	return k
+}
+
+// DeclareKeys declares the bindings managing tabs, then the bindings of the tab contents under the tab names.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.AddKeyMap(owner, keys.Screen, m.keyMap())
+	for i, content := range m.TabContents {
+		registry.Declare(content, owner+"/"+m.Tabs[i])
+	}
+}
+
+// CapturingInput tells whether the confirmation of a close is asked, the tab picker is open, or the content of the
+// active tab is capturing text input.
+func (m *Model) CapturingInput() bool {
+	return m.closing || m.picking || (len(m.TabContents) > 0 && keys.Capturing(m.TabContents[m.ActiveTab]))
+}
+
+// KeyHelp returns the bindings managing tabs, followed by the bindings of the active tab.
+func (m *Model) KeyHelp() []keys.Group {
+	k := m.keyMap()
+	if m.closing {
+		return []keys.Group{{Title: "Tabs", Bindings: []key.Binding{k.Confirm, k.Cancel}}}
+	}
+	if m.picking {
+		return []keys.Group{{Title: "Tabs", Bindings: []key.Binding{k.PickerUp, k.PickerDown, k.PickerSelect, k.Quit}}}
+	}
+
+	groups := []keys.Group{{Title: "Tabs", Bindings: k.ShortHelp()}}
+	if len(m.TabContents) == 0 {
+		return groups
+	}
+	return append(groups, keys.Help(m.TabContents[m.ActiveTab])...)
+}
+
+func insert[T any](values []T, index int, value T) []T {
+	values = append(values, value)
+	copy(values[index+1:], values[index:])
+	values[index] = value
+	return values
+}
+
+func remove[T any](values []T, index int) []T {
+	return append(values[:index:index], values[index+1:]...)
+}
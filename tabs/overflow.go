@@ -0,0 +1,136 @@
+package tabs
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"strings"
+)
+
+// defaultMaxTitleWidth is the width above which tab titles are truncated, unless set with WithMaxTitleWidth.
+const defaultMaxTitleWidth = 24
+
+// headerItem is a block of the header row: a tab, or a scroll indicator selecting the tab next to the visible ones.
+type headerItem struct {
+	view string
+	tab  int
+}
+
+// headerItems lays out the header row. When the tabs are wider than the row, the header scrolls to keep the
+// active tab visible, and indicators show the tabs hidden on each side.
+func (m *Model) headerItems() []headerItem {
+	tabs := m.renderTabs()
+
+	space := m.headerSpace()
+	if space <= 0 || m.extents(tabs) <= space {
+		m.firstTab = 0
+		items := make([]headerItem, len(tabs))
+		for i, tab := range tabs {
+			items[i] = headerItem{view: tab, tab: i}
+		}
+		return items
+	}
+
+	previous, next := m.indicator("‹"), m.indicator("›")
+	if m.vertical() {
+		previous, next = m.indicator("↑"), m.indicator("↓")
+	}
+
+	// Room is kept for both indicators.
+	available := space - m.extent(previous) - m.extent(next)
+
+	m.firstTab = min(m.firstTab, m.ActiveTab)
+	for m.firstTab < m.ActiveTab && m.extents(tabs[m.firstTab:m.ActiveTab+1]) > available {
+		m.firstTab++
+	}
+	last := m.firstTab
+	for last+1 < len(tabs) && m.extents(tabs[m.firstTab:last+2]) <= available {
+		last++
+	}
+
+	var items []headerItem
+	if m.firstTab > 0 {
+		items = append(items, headerItem{view: previous, tab: m.firstTab - 1})
+	}
+	for i := m.firstTab; i <= last; i++ {
+		items = append(items, headerItem{view: tabs[i], tab: i})
+	}
+	if last < len(tabs)-1 {
+		items = append(items, headerItem{view: next, tab: last + 1})
+	}
+	return items
+}
+
+// overflows tells whether some tabs are hidden by the scrolling of the header.
+func (m *Model) overflows() bool {
+	return len(m.headerItems()) != len(m.Tabs)
+}
+
+// indicator renders a scroll indicator next to the separator between the tabs and the content.
+func (m *Model) indicator(arrow string) string {
+	return m.separatorStyle().Render(arrow)
+}
+
+// extents returns the size of header blocks along the tabs.
+func (m *Model) extents(views []string) int {
+	total := 0
+	for _, view := range views {
+		total += m.extent(view)
+	}
+	return total
+}
+
+// truncateTitle shortens a tab title to the maximum title width, and to the row width so that a single tab always fits
+// with its status, which takes the reserved width.
+func (m *Model) truncateTitle(title string, reserved int) string {
+	// The borders of the tab and the scroll indicators take 2 columns each.
+	limit := min(m.maxTitleWidth, m.maxWidth-4-reserved)
+	return runewidth.Truncate(title, max(1, limit), "…")
+}
+
+// openPicker lists every tab, the active one under the cursor.
+func (m *Model) openPicker() {
+	m.picking = true
+	m.pickCursor = m.ActiveTab
+}
+
+// updatePicker moves the cursor of the tab picker, and shows the chosen tab.
+func (m *Model) updatePicker(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.KeyMap.PickerUp):
+		m.pickCursor = max(m.pickCursor-1, 0)
+	case key.Matches(msg, m.KeyMap.PickerDown):
+		m.pickCursor = min(m.pickCursor+1, len(m.Tabs)-1)
+	case key.Matches(msg, m.KeyMap.PickerSelect):
+		m.ActiveTab = m.pickCursor
+		m.picking = false
+	case key.Matches(msg, m.KeyMap.Picker), key.Matches(msg, m.KeyMap.Quit):
+		m.picking = false
+	}
+	return nil
+}
+
+// pickerView lists the tab titles, scrolled to keep the cursor visible in the height of the content.
+func (m *Model) pickerView() string {
+	lines := []string{m.pickerTitleStyle.Render("Tabs")}
+
+	width, height := m.contentSize()
+	visible := max(1, height-lipgloss.Height(lines[0]))
+	first := max(0, m.pickCursor-visible+1)
+	last := min(first+visible, len(m.Tabs))
+
+	for i := first; i < last; i++ {
+		title := runewidth.Truncate(m.Tabs[i], max(1, width-4), "…")
+		if i == m.ActiveTab {
+			title += " •"
+		}
+		if i == m.pickCursor {
+			lines = append(lines, m.pickerSelectedStyle.Render("› "+title))
+		} else {
+			lines = append(lines, "  "+title)
+		}
+	}
+
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
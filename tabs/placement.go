@@ -0,0 +1,197 @@
+package tabs
+
+import (
+	"github.com/Funkit/theiere/mouse"
+	"github.com/charmbracelet/lipgloss"
+	"math"
+)
+
+// Placement is the side of the content where the tabs are drawn.
+type Placement int
+
+const (
+	Top Placement = iota
+	Bottom
+	Left
+	Right
+)
+
+var (
+	// Below the content, the active tab has no top border.
+	bottomActiveTabBorder = lipgloss.Border{
+		Top:         " ",
+		Bottom:      "─",
+		Left:        "│",
+		Right:       "│",
+		TopLeft:     "┐",
+		TopRight:    "┌",
+		BottomLeft:  "╰",
+		BottomRight: "╯",
+	}
+
+	bottomInactiveTabBorder = lipgloss.Border{
+		Top:         "─",
+		Bottom:      "─",
+		Left:        "│",
+		Right:       "│",
+		TopLeft:     "┬",
+		TopRight:    "┬",
+		BottomLeft:  "╰",
+		BottomRight: "╯",
+	}
+
+	// On the left of the content, the active tab has no right border.
+	leftActiveTabBorder = lipgloss.Border{
+		Top:         "─",
+		Bottom:      "─",
+		Left:        "│",
+		Right:       " ",
+		TopLeft:     "╭",
+		TopRight:    "┘",
+		BottomLeft:  "╰",
+		BottomRight: "┐",
+	}
+
+	leftInactiveTabBorder = lipgloss.Border{
+		Top:         "─",
+		Bottom:      "─",
+		Left:        "│",
+		Right:       "│",
+		TopLeft:     "╭",
+		TopRight:    "┤",
+		BottomLeft:  "╰",
+		BottomRight: "┤",
+	}
+
+	// On the right of the content, the active tab has no left border.
+	rightActiveTabBorder = lipgloss.Border{
+		Top:         "─",
+		Bottom:      "─",
+		Left:        " ",
+		Right:       "│",
+		TopLeft:     "└",
+		TopRight:    "╮",
+		BottomLeft:  "┌",
+		BottomRight: "╯",
+	}
+
+	rightInactiveTabBorder = lipgloss.Border{
+		Top:         "─",
+		Bottom:      "─",
+		Left:        "│",
+		Right:       "│",
+		TopLeft:     "├",
+		TopRight:    "╮",
+		BottomLeft:  "├",
+		BottomRight: "╯",
+	}
+)
+
+// WithPlacement sets the side of the content where the tabs are drawn, on top by default.
+// On the left and on the right, the tabs are stacked in a sidebar and switched with ctrl+up and ctrl+down.
+func WithPlacement(placement Placement) Option {
+	return func(options *options) error {
+		options.placement = placement
+
+		return nil
+	}
+}
+
+// borders returns the borders of the active and inactive tabs for a placement.
+func borders(placement Placement) (active, inactive lipgloss.Border) {
+	switch placement {
+	case Bottom:
+		return bottomActiveTabBorder, bottomInactiveTabBorder
+	case Left:
+		return leftActiveTabBorder, leftInactiveTabBorder
+	case Right:
+		return rightActiveTabBorder, rightInactiveTabBorder
+	}
+	return activeTabBorder, inactiveTabBorder
+}
+
+func (m *Model) vertical() bool {
+	return m.placement == Left || m.placement == Right
+}
+
+// separatorStyle draws the line between the tabs and the content, next to the tabs.
+func (m *Model) separatorStyle() lipgloss.Style {
+	style := m.inactiveTabStyle.Copy().
+		BorderTop(m.placement == Bottom).
+		BorderBottom(m.placement == Top).
+		BorderLeft(m.placement == Right).
+		BorderRight(m.placement == Left)
+	if m.vertical() {
+		style = style.Width(m.sidebarWidth() - 1).Align(lipgloss.Center)
+	}
+	return style
+}
+
+// sidebarWidth returns the width of the vertical tabs: the widest title, inside the tab borders.
+func (m *Model) sidebarWidth() int {
+	width := 1
+	for i := range m.Tabs {
+		width = max(width, lipgloss.Width(m.label(i, false)))
+	}
+	return width + 2
+}
+
+// contentSize returns the size given to the tab contents, next to the tabs.
+func (m *Model) contentSize() (width, height int) {
+	if m.vertical() {
+		return m.maxWidth - m.sidebarWidth(), m.height
+	}
+	return m.maxWidth - 2, m.height - 2
+}
+
+// resize gives their size to the tab contents, which changes with the width of the sidebar.
+func (m *Model) resize() {
+	width, height := m.contentSize()
+	for i := range m.TabContents {
+		m.TabContents[i].SetWidth(width)
+		if m.height > 0 {
+			m.TabContents[i].SetHeight(height)
+		}
+	}
+}
+
+// extent returns the size of a header block along the tabs.
+func (m *Model) extent(view string) int {
+	if m.vertical() {
+		return lipgloss.Height(view)
+	}
+	return lipgloss.Width(view)
+}
+
+// headerSpace returns the room available for the tabs along the header, 0 when unknown.
+func (m *Model) headerSpace() int {
+	if m.vertical() {
+		return m.height
+	}
+	return m.maxWidth
+}
+
+// layout joins the header and the body as placed, and returns where each of them is drawn.
+func (m *Model) layout(header, body string) (view string, headerX, headerY, bodyX, bodyY int) {
+	headerWidth, headerHeight := mouse.Size(header)
+	bodyWidth, bodyHeight := mouse.Size(body)
+
+	// JoinVertical centers the narrower block, rounding its left gap up.
+	centered := func(outer, inner int) int {
+		return int(math.Round(float64(max(0, outer-inner)) / 2))
+	}
+
+	switch m.placement {
+	case Bottom:
+		view = lipgloss.JoinVertical(lipgloss.Center, body, header)
+		return view, centered(bodyWidth, headerWidth), bodyHeight, centered(headerWidth, bodyWidth), 0
+	case Left:
+		return lipgloss.JoinHorizontal(lipgloss.Top, header, body), 0, 0, headerWidth, 0
+	case Right:
+		width, _ := m.contentSize()
+		body = lipgloss.NewStyle().Width(width).Render(body)
+		return lipgloss.JoinHorizontal(lipgloss.Top, body, header), lipgloss.Width(body), 0, 0, 0
+	}
+	view = lipgloss.JoinVertical(lipgloss.Center, header, body)
+	return view, centered(bodyWidth, headerWidth), 0, centered(headerWidth, bodyWidth), headerHeight
+}
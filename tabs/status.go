@@ -0,0 +1,154 @@
+package tabs
+
+import (
+	"fmt"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+// maxBadge is the largest count shown in a badge, higher counts being shown as "99+".
+const maxBadge = 99
+
+// Status is the metadata shown in a tab header around its title.
+type Status struct {
+	// Icon is shown before the title.
+	Icon string
+	// Badge is a count, like unread items, shown after the title unless it is 0.
+	Badge int
+	// Error marks the title with a dot.
+	Error bool
+	// Loading shows a spinner in place of the icon.
+	Loading bool
+}
+
+// StatusMsg replaces the status of the tab with the given ID. Tab contents send it to update their header.
+// Tabs nested in the contents are searched when no tab has this ID.
+type StatusMsg struct {
+	Tab    string
+	Status Status
+}
+
+func (StatusMsg) Upward() {}
+
+// UpdateStatus returns the command replacing the status of the tab with the given ID.
+func UpdateStatus(tab string, status Status) tea.Cmd {
+	return func() tea.Msg {
+		return StatusMsg{Tab: tab, Status: status}
+	}
+}
+
+// SetStatus replaces the status of the tab with the given ID. The returned command animates the spinner of loading tabs.
+func (m *Model) SetStatus(id string, status Status) (tea.Cmd, error) {
+	index := m.tabIndex(id)
+	if index < 0 {
+		return nil, fmt.Errorf("unknown tab %q", id)
+	}
+
+	m.statuses[index] = status
+	// The sidebar may widen with the status.
+	m.resize()
+
+	return m.spin(), nil
+}
+
+// Status returns the status of the tab with the given ID.
+func (m *Model) Status(id string) (Status, bool) {
+	index := m.tabIndex(id)
+	if index < 0 {
+		return Status{}, false
+	}
+	return m.statuses[index], true
+}
+
+func (m *Model) tabIndex(id string) int {
+	for i, tabID := range m.ids {
+		if tabID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateStatus applies a status message to the tab with its ID, in these tabs or in nested ones.
+func (m *Model) updateStatus(msg StatusMsg) (bool, tea.Cmd) {
+	if m.tabIndex(msg.Tab) < 0 {
+		for _, content := range m.TabContents {
+			if sub, ok := content.(*Model); ok {
+				if handled, cmd := sub.updateStatus(msg); handled {
+					return true, cmd
+				}
+			}
+		}
+		return false, nil
+	}
+
+	cmd, err := m.SetStatus(msg.Tab, msg.Status)
+	m.err = err
+	return true, cmd
+}
+
+// spin starts the spinner when a tab is loading. It is not started again while it runs.
+func (m *Model) spin() tea.Cmd {
+	if m.spinning || !m.loading() || theme.CurrentAccess().ReducedMotion {
+		return nil
+	}
+	m.spinning = true
+	return m.spinner.Tick
+}
+
+// updateSpinner animates the spinner until no tab is loading.
+func (m *Model) updateSpinner(msg spinner.TickMsg) tea.Cmd {
+	if !m.loading() || theme.CurrentAccess().ReducedMotion {
+		m.spinning = false
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+func (m *Model) loading() bool {
+	for _, status := range m.statuses {
+		if status.Loading {
+			return true
+		}
+	}
+	return false
+}
+
+// label renders the title of a tab with its status: the spinner or the icon before it, the badge and the error dot
+// after it. The title is truncated so that the whole label fits.
+func (m *Model) label(index int, active bool) string {
+	status := m.statuses[index]
+
+	var before, after []string
+	switch {
+	case status.Loading && theme.CurrentAccess().ReducedMotion:
+		before = append(before, "…")
+	case status.Loading:
+		before = append(before, m.spinner.View())
+	case status.Icon != "":
+		before = append(before, status.Icon)
+	}
+	if status.Badge > 0 {
+		badge := fmt.Sprint(status.Badge)
+		if status.Badge > maxBadge {
+			badge = fmt.Sprintf("%d+", maxBadge)
+		}
+		after = append(after, m.badgeStyle.Render("("+badge+")"))
+	}
+	if status.Error {
+		after = append(after, m.errorDotStyle.Render("●"))
+	}
+
+	reserved := 0
+	for _, part := range append(before, after...) {
+		// Each part is followed or preceded by a space.
+		reserved += lipgloss.Width(part) + 1
+	}
+	parts := append(before, m.truncateTitle(m.Tabs[index], reserved))
+	return theme.Marked(strings.Join(append(parts, after...), " "), active)
+}
@@ -1,11 +1,16 @@
 package tabs
 
 import (
+	"fmt"
 	"github.com/Funkit/theiere/fancytext"
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/mouse"
+	"github.com/Funkit/theiere/palette"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
-	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"strings"
@@ -35,7 +40,6 @@ var (
 		BottomLeft:  "┴",
 		BottomRight: "┴",
 	}
-	helpStyle = list.DefaultStyles().HelpStyle
 )
 
 type Model struct {
@@ -45,16 +49,58 @@ type Model struct {
 	inactiveTabStyle lipgloss.Style
 	activeTabStyle   lipgloss.Style
 	KeyMap           KeyMap
-	Help             help.Model
-	maxWidth         int
+	// Deprecated: the help is shown by the frame help bar, from KeyHelp. Help is no longer rendered.
+	Help          help.Model
+	maxWidth      int
+	height        int
+	color         *lipgloss.AdaptiveColor
+	closeable     []bool
+	factory       func() (Tab, error)
+	closing       bool
+	err           error
+	promptStyle   lipgloss.Style
+	errorStyle    lipgloss.Style
+	firstTab      int
+	maxTitleWidth int
+	placement     Placement
+	ids           []string
+	statuses      []Status
+	spinner       spinner.Model
+	spinning      bool
+	badgeStyle    lipgloss.Style
+	errorDotStyle lipgloss.Style
+	// shown is the tab displayed at the last update, -1 when none was. Only the initialized tabs got their Init.
+	shown         int
+	initialized   []bool
+	resetOnSwitch bool
+	id            string
+	picking       bool
+	pickCursor    int
+	// pickerTitleStyle and pickerSelectedStyle style the list of tabs opened when they overflow the header.
+	pickerTitleStyle    lipgloss.Style
+	pickerSelectedStyle lipgloss.Style
 }
 
-// Tab each tab is defined by its title and its content
+// Tab each tab is defined by its title and its content.
+// A closeable tab can be closed with the close key.
+// The ID targets the tab with a StatusMsg, the name being used if it is empty.
 type Tab struct {
-	Name    string
-	Content subview.Model
+	Name      string
+	Content   subview.Model
+	Closeable bool
+	ID        string
+	Status    Status
 }
 
+// SelectTabMsg switches to the tab at Index in the tabs with the ID set by WithTabsID.
+// Tabs nested in the contents are searched when the ID differs.
+type SelectTabMsg struct {
+	Tabs  string
+	Index int
+}
+
+func (SelectTabMsg) Upward() {}
+
 func NewTab(name string, content ...subview.Model) (Tab, error) {
 	if len(content) != 0 {
 		return Tab{
@@ -75,13 +121,28 @@ func NewTab(name string, content ...subview.Model) (Tab, error) {
 }
 
 type options struct {
-	width     *int
-	color     *lipgloss.AdaptiveColor
-	fixedSize bool
+	width         *int
+	color         *lipgloss.AdaptiveColor
+	fixedSize     bool
+	keyMap        *KeyMap
+	factory       func() (Tab, error)
+	maxTitle      *int
+	placement     Placement
+	resetOnSwitch bool
+	id            string
 }
 
 type Option func(options *options) error
 
+// WithKeyMap replaces the default key bindings.
+func WithKeyMap(keyMap KeyMap) Option {
+	return func(options *options) error {
+		options.keyMap = &keyMap
+
+		return nil
+	}
+}
+
 func WithWidth(width int) Option {
 	return func(options *options) error {
 		options.width = &width
@@ -98,6 +159,36 @@ func WithColor(color lipgloss.AdaptiveColor) Option {
 	}
 }
 
+// WithMaxTitleWidth sets the width above which tab titles are truncated with an ellipsis.
+func WithMaxTitleWidth(width int) Option {
+	return func(options *options) error {
+		if width < 1 {
+			return fmt.Errorf("invalid maximum title width %d", width)
+		}
+		options.maxTitle = &width
+
+		return nil
+	}
+}
+
+// WithTabsID sets the ID used to target these tabs with a SelectTabMsg, AddTabMsg, RemoveTabMsg or MoveTabMsg.
+func WithTabsID(id string) Option {
+	return func(options *options) error {
+		options.id = id
+
+		return nil
+	}
+}
+
+// WithNewTab enables the key opening a new tab, built by the factory and added after the others.
+func WithNewTab(factory func() (Tab, error)) Option {
+	return func(options *options) error {
+		options.factory = factory
+
+		return nil
+	}
+}
+
 // New builds a new tab model. Will panic if tab.content is not set to an actual common.Model.
 func New(availableTabs []Tab, opts ...Option) (*Model, error) {
 	var options options
@@ -113,61 +204,185 @@ func New(availableTabs []Tab, opts ...Option) (*Model, error) {
 		width = *options.width
 	}
 
-	color := lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"}
-	if options.color != nil {
-		color = *options.color
-	}
-
 	var tabNames []string
 	var tabElements []subview.Model
+	var closeable []bool
+	var ids []string
+	var statuses []Status
 	for _, val := range availableTabs {
 		tabNames = append(tabNames, val.Name)
 		tabElements = append(tabElements, val.Content)
+		closeable = append(closeable, val.Closeable)
+		ids = append(ids, tabID(val))
+		statuses = append(statuses, val.Status)
 	}
 
-	inactiveTabStyle := lipgloss.NewStyle().Border(inactiveTabBorder, true).BorderForeground(color)
+	keyMap := DefaultKeyMap()
+	if options.keyMap != nil {
+		keyMap = *options.keyMap
+	}
 
-	return &Model{
-		Tabs:             tabNames,
-		TabContents:      tabElements,
-		ActiveTab:        0,
-		inactiveTabStyle: inactiveTabStyle,
-		activeTabStyle:   inactiveTabStyle.Copy().Border(activeTabBorder, true),
-		Help:             help.New(),
-		KeyMap:           DefaultKeyMap(),
-		maxWidth:         width,
-	}, nil
+	maxTitleWidth := defaultMaxTitleWidth
+	if options.maxTitle != nil {
+		maxTitleWidth = *options.maxTitle
+	}
+
+	m := &Model{
+		Tabs:          tabNames,
+		TabContents:   tabElements,
+		ActiveTab:     0,
+		KeyMap:        keyMap,
+		Help:          help.New(),
+		maxWidth:      width,
+		color:         options.color,
+		closeable:     closeable,
+		factory:       options.factory,
+		maxTitleWidth: maxTitleWidth,
+		placement:     options.placement,
+		ids:           ids,
+		statuses:      statuses,
+		spinner:       spinner.New(),
+		shown:         -1,
+		initialized:   make([]bool, len(availableTabs)),
+		resetOnSwitch: options.resetOnSwitch,
+		id:            options.id,
+	}
+	m.setStyles(theme.Current())
+
+	return m, nil
+}
+
+// setStyles builds the tab styles from a theme, unless the color was set with WithColor.
+func (m *Model) setStyles(t theme.Theme) {
+	color := t.Border
+	if m.color != nil {
+		color = *m.color
+	}
+
+	active, inactive := borders(m.placement)
+	m.inactiveTabStyle = lipgloss.NewStyle().Border(inactive, true).BorderForeground(color)
+	m.activeTabStyle = m.inactiveTabStyle.Copy().Border(active, true)
+	m.promptStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Accent).PaddingLeft(1)
+	m.errorStyle = lipgloss.NewStyle().Foreground(t.Danger).PaddingLeft(1)
+	m.pickerTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Contrast).Background(t.Primary).Padding(0, 1).MarginBottom(1)
+	m.pickerSelectedStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
+	m.badgeStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Accent)
+	m.errorDotStyle = lipgloss.NewStyle().Foreground(t.Danger)
+	m.spinner.Style = lipgloss.NewStyle().Foreground(t.Accent)
 }
 
+// Init initializes the first tab shown, the other tabs being initialized when first shown.
+// It also starts the spinner of the tabs loading from the start.
 func (m *Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.sync(), m.spin())
 }
 
+// Update passes key and mouse events, and the other messages not sent to a tab with a TabMsg, to the active tab.
+// The commands of the tabs are routed to them. The active tab is initialized when it is first shown.
 func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	cmd := m.update(msg)
+	return m, tea.Batch(cmd, m.sync())
+}
+
+func (m *Model) update(msg tea.Msg) tea.Cmd {
+	if handled, cmd := m.updateTabs(msg); handled {
+		return cmd
+	}
+
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
 	case tea.KeyMsg:
+		m.err = nil
+		if m.closing {
+			return m.updateClosing(msg)
+		}
+		if m.picking {
+			return m.updatePicker(msg)
+		}
+		if len(m.TabContents) == 0 {
+			return m.updateEmpty(msg)
+		}
+		if !keys.Handles(keys.Screen, msg, m.TabContents[m.ActiveTab]) {
+			break
+		}
 		switch {
-		case key.Matches(msg, m.KeyMap.PrevPage):
+		case key.Matches(msg, m.KeyMap.PrevPage), m.vertical() && key.Matches(msg, m.KeyMap.Up):
 			m.ActiveTab = max(m.ActiveTab-1, 0)
-			return m, nil
-		case key.Matches(msg, m.KeyMap.NextPage):
+			return nil
+		case key.Matches(msg, m.KeyMap.NextPage), m.vertical() && key.Matches(msg, m.KeyMap.Down):
 			m.ActiveTab = min(m.ActiveTab+1, len(m.Tabs)-1)
-			return m, nil
+			return nil
+		case key.Matches(msg, m.KeyMap.Close) && m.closeable[m.ActiveTab]:
+			return m.requestClose()
+		case key.Matches(msg, m.KeyMap.New) && m.factory != nil:
+			return m.newTab()
+		case key.Matches(msg, m.KeyMap.Picker) && m.overflows():
+			m.openPicker()
+			return nil
+		// Moving the first tab left or the last one right does nothing.
+		case key.Matches(msg, m.KeyMap.MoveLeft):
+			if m.ActiveTab > 0 {
+				m.err = m.MoveTab(m.ActiveTab, m.ActiveTab-1)
+			}
+			return nil
+		case key.Matches(msg, m.KeyMap.MoveRight):
+			if m.ActiveTab < len(m.Tabs)-1 {
+				m.err = m.MoveTab(m.ActiveTab, m.ActiveTab+1)
+			}
+			return nil
 		case key.Matches(msg, m.KeyMap.Quit):
-			return m, subview.GoUp
+			return subview.GoUp
 		}
-		m.TabContents[m.ActiveTab], _ = m.TabContents[m.ActiveTab].Update(msg)
 	}
 
-	_, cmd := m.TabContents[m.ActiveTab].Update(msg)
+	if len(m.TabContents) == 0 {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.TabContents[m.ActiveTab], cmd = m.TabContents[m.ActiveTab].Update(msg)
 
-	return m, cmd
+	return Route(m.ids[m.ActiveTab], cmd)
 }
 
 func (m *Model) View() string {
+	view, _, _, _, _ := m.layout(m.headerView(), m.bodyView())
+	return view
+}
+
+// bodyView renders the content of the active tab, or the tab picker, below the status line.
+func (m *Model) bodyView() string {
+	content := ""
+	switch {
+	case m.picking:
+		content = m.pickerView()
+	case len(m.TabContents) > 0:
+		content = m.TabContents[m.ActiveTab].View()
+	}
+	if status := m.statusView(); status != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, status, content)
+	}
+	return content
+}
+
+// statusView shows the confirmation asked before closing a tab, or the last error.
+func (m *Model) statusView() string {
+	switch {
+	case m.closing:
+		return m.promptStyle.Render(fmt.Sprintf("Close %q? Its changes will be lost. (%s/%s)",
+			m.Tabs[m.ActiveTab], m.KeyMap.Confirm.Help().Key, m.KeyMap.Cancel.Help().Key))
+	case m.err != nil:
+		return m.errorStyle.Render(m.err.Error())
+	}
+	return ""
+}
+
+// renderTabs renders the header of each tab.
+func (m *Model) renderTabs() []string {
 	var renderedTabs []string
 
-	for i, t := range m.Tabs {
+	for i := range m.Tabs {
 		var style lipgloss.Style
 		isActive := i == m.ActiveTab
 		if isActive {
@@ -177,41 +392,101 @@ func (m *Model) View() string {
 		}
 		border, _, _, _, _ := style.GetBorder()
 		style = style.Border(border)
-		renderedTabs = append(renderedTabs, style.Render(t))
+		if m.vertical() {
+			style = style.Width(m.sidebarWidth() - 2)
+		}
+		renderedTabs = append(renderedTabs, style.Render(m.label(i, isActive)))
 	}
 
-	row := lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...)
+	return renderedTabs
+}
 
-	tabGap := m.inactiveTabStyle.Copy().
-		BorderTop(false).
-		BorderLeft(false).
-		BorderRight(false)
+// headerView renders the row of tab headers, extended to the full width.
+func (m *Model) headerView() string {
+	var views []string
+	for _, item := range m.headerItems() {
+		views = append(views, item.view)
+	}
 
+	switch m.placement {
+	case Bottom:
+		row := lipgloss.JoinHorizontal(lipgloss.Top, views...)
+		tabGapStr := strings.Repeat(" ", max(0, m.maxWidth-lipgloss.Width(row)))
+		return lipgloss.JoinHorizontal(lipgloss.Top, row, m.separatorStyle().Render(tabGapStr))
+	case Left, Right:
+		column := lipgloss.JoinVertical(lipgloss.Left, views...)
+		gap := m.height - lipgloss.Height(column)
+		if gap <= 0 {
+			return column
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, column, m.separatorStyle().Height(gap).Render(""))
+	}
+
+	row := lipgloss.JoinHorizontal(lipgloss.Bottom, views...)
 	tabGapStr := strings.Repeat(" ", max(0, m.maxWidth-lipgloss.Width(row)))
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, row, m.separatorStyle().Render(tabGapStr))
+}
 
-	row = lipgloss.JoinHorizontal(lipgloss.Bottom, row, tabGap.Render(tabGapStr))
+// updateMouse switches to a clicked tab, and passes the other events to the active tab in its own coordinates.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	header := m.headerView()
+	headerWidth, headerHeight := mouse.Size(header)
+	_, headerX, headerY, bodyX, bodyY := m.layout(header, m.bodyView())
 
-	row = lipgloss.JoinVertical(lipgloss.Center, row, m.TabContents[m.ActiveTab].View())
-	return lipgloss.JoinVertical(lipgloss.Left, row, helpStyle.Render(m.Help.View(m.KeyMap)))
+	if mouse.In(msg, headerX, headerY, headerWidth, headerHeight) {
+		if msg.Type != tea.MouseLeft {
+			return nil
+		}
+		msg = mouse.Translate(msg, headerX, headerY)
+		offset := 0
+		for _, item := range m.headerItems() {
+			extent := m.extent(item.view)
+			if m.vertical() && mouse.In(msg, 0, offset, headerWidth, extent) ||
+				!m.vertical() && mouse.In(msg, offset, 0, extent, headerHeight) {
+				m.ActiveTab = item.tab
+				break
+			}
+			offset += extent
+		}
+		return nil
+	}
+
+	if m.closing || m.picking || len(m.TabContents) == 0 {
+		return nil
+	}
+
+	// The status line, when shown, is drawn above the content.
+	if status := m.statusView(); status != "" {
+		bodyY += lipgloss.Height(status)
+	}
+
+	var cmd tea.Cmd
+	m.TabContents[m.ActiveTab], cmd = m.TabContents[m.ActiveTab].Update(mouse.Translate(msg, bodyX, bodyY))
+	return Route(m.ids[m.ActiveTab], cmd)
 }
 
 func (m *Model) SetWidth(width int) {
 	m.maxWidth = width
-	for i := range m.TabContents {
-		m.TabContents[i].SetWidth(width - 2)
-	}
+	m.resize()
 }
 
 func (m *Model) SetHeight(height int) {
-	for i := range m.TabContents {
-		m.TabContents[i].SetHeight(height - 2)
-	}
+	m.height = height
+	m.resize()
 }
 
 func (m *Model) Reset() {
 	m.ActiveTab = 0
+	m.closing = false
+	m.picking = false
+	m.firstTab = 0
+	m.err = nil
+	// The spinner stops while the tabs are hidden; it starts again with the next status.
+	m.spinning = false
+	m.shown = -1
 	for i := 0; i < len(m.TabContents); i++ {
 		m.TabContents[i].Reset()
+		m.initialized[i] = false
 	}
 }
 
@@ -228,3 +503,41 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// Commands returns a palette command switching to each tab, followed by the commands of the tab contents,
+// which switch to their tab before running.
+func (m *Model) Commands() []palette.Command {
+	var commands []palette.Command
+	for i, name := range m.Tabs {
+		commands = append(commands, palette.Command{
+			ID:       "tabs/" + name,
+			Title:    name,
+			Category: "Tabs",
+			Run:      m.selectTab(i),
+		})
+	}
+	for i, content := range m.TabContents {
+		if commander, ok := content.(palette.Commander); ok {
+			for _, command := range commander.Commands() {
+				command.Run = tea.Sequence(m.selectTab(i), command.Run)
+				commands = append(commands, command)
+			}
+		}
+	}
+	return commands
+}
+
+func (m *Model) selectTab(index int) tea.Cmd {
+	id := m.id
+	return func() tea.Msg {
+		return SelectTabMsg{Tabs: id, Index: index}
+	}
+}
+
+// SetTheme applies a theme to the tabs and to the content of every tab.
+func (m *Model) SetTheme(t theme.Theme) {
+	m.setStyles(t)
+	for _, content := range m.TabContents {
+		theme.Apply(content, t)
+	}
+}
@@ -0,0 +1,46 @@
+package theme
+
+import "os"
+
+// Access holds the accessibility settings shared by the components.
+type Access struct {
+	// NoColor renders the themes without any color. Text attributes like bold and underline are kept.
+	NoColor bool
+	// Markers shows states with text, like brackets around the active tab, instead of relying on colors only.
+	Markers bool
+	// ReducedMotion replaces animations, like the executor spinner, with static text.
+	ReducedMotion bool
+}
+
+var access Access
+
+// AccessFromEnv returns the settings requested by the environment. A non-empty NO_COLOR variable disables the colors,
+// and enables the markers since the states would not be visible otherwise.
+func AccessFromEnv() Access {
+	if os.Getenv("NO_COLOR") != "" {
+		return Access{NoColor: true, Markers: true}
+	}
+	return Access{}
+}
+
+// SetAccess sets the accessibility settings. Like Set, it is meant to be called once, at the root of the application.
+func SetAccess(a Access) {
+	access = a
+}
+
+// CurrentAccess returns the accessibility settings in use, none unless SetAccess was called.
+func CurrentAccess() Access {
+	return access
+}
+
+// Marked returns a label with a marker showing whether it is active, like "[Save]", when the markers are enabled.
+// Inactive labels are padded to the same width.
+func Marked(label string, active bool) string {
+	if !access.Markers {
+		return label
+	}
+	if active {
+		return "[" + label + "]"
+	}
+	return " " + label + " "
+}
@@ -0,0 +1,32 @@
+package theme
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Changed switches the theme at runtime. The root frame sets it as the current theme and applies it
+// to the whole component tree.
+type Changed struct {
+	Theme Theme
+}
+
+// Upward lets the message reach the root frame from any component.
+func (Changed) Upward() {}
+
+// Use returns the command switching to a theme.
+func Use(t Theme) tea.Cmd {
+	return func() tea.Msg {
+		return Changed{Theme: t}
+	}
+}
+
+// Themed is implemented by the components whose colors can change after they are built.
+// Containers apply the theme to all their content, displayed or not.
+type Themed interface {
+	SetTheme(t Theme)
+}
+
+// Apply applies a theme to a component, if it is Themed. The colors are left out when NoColor is set.
+func Apply(component any, t Theme) {
+	if themed, ok := component.(Themed); ok {
+		themed.SetTheme(colored(t))
+	}
+}
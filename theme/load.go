@@ -0,0 +1,181 @@
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Dir returns the directory holding the user theme files.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "theiere", "themes"), nil
+}
+
+// LoadDir reads the JSON and TOML theme files of a directory and registers them.
+// A missing directory is not an error. Invalid files are skipped, the returned error listing them.
+func LoadDir(dir string) ([]Theme, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded []Theme
+	var failed []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+		t, err := LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", entry.Name(), err))
+			continue
+		}
+		Register(t)
+		loaded = append(loaded, t)
+	}
+	if len(failed) > 0 {
+		return loaded, fmt.Errorf("invalid theme files: %s", strings.Join(failed, "; "))
+	}
+	return loaded, nil
+}
+
+// LoadFile reads a theme from a JSON or TOML file. Theme files define colors by token name, either as
+// a single color or with light and dark variants. Missing tokens keep the color of the default theme,
+// and the name defaults to the file name.
+//
+//	name = "ocean"
+//	primary = "#0077B6"
+//	muted = { light = "#8D99AE", dark = "#5C677D" }
+func LoadFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	values := make(map[string]any)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return Theme{}, fmt.Errorf("unsupported theme file format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return Theme{}, fmt.Errorf("cannot read theme from %s: %w", path, err)
+	}
+
+	t, err := parse(values)
+	if err != nil {
+		return Theme{}, fmt.Errorf("invalid theme in %s: %w", path, err)
+	}
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return t, nil
+}
+
+func parse(values map[string]any) (Theme, error) {
+	t := Default
+	t.Name = ""
+	tokens := map[string]*lipgloss.AdaptiveColor{
+		"primary":    &t.Primary,
+		"accent":     &t.Accent,
+		"success":    &t.Success,
+		"danger":     &t.Danger,
+		"muted":      &t.Muted,
+		"border":     &t.Border,
+		"selected":   &t.Selected,
+		"contrast":   &t.Contrast,
+		"subtle":     &t.Subtle,
+		"title":      &t.Title,
+		"titletext":  &t.TitleText,
+		"cursor":     &t.Cursor,
+		"cursordesc": &t.CursorDesc,
+		"dimmed":     &t.Dimmed,
+		"dimmeddesc": &t.DimmedDesc,
+		"dialog":     &t.Dialog,
+		"button":     &t.Button,
+		"buttontext": &t.ButtonText,
+	}
+
+	var problems []string
+	for name, value := range values {
+		if name == "name" {
+			s, ok := value.(string)
+			if !ok {
+				problems = append(problems, "name must be a string")
+			}
+			t.Name = s
+			continue
+		}
+
+		token, ok := tokens[strings.ToLower(name)]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown token %q", name))
+			continue
+		}
+		color, err := parseColor(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		*token = color
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return Theme{}, errors.New(strings.Join(problems, "; "))
+	}
+	return t, nil
+}
+
+// parseColor reads a color, either a string used for both variants or an object with light and dark variants.
+func parseColor(value any) (lipgloss.AdaptiveColor, error) {
+	switch v := value.(type) {
+	case string:
+		if !validColor(v) {
+			return lipgloss.AdaptiveColor{}, fmt.Errorf("invalid color %q", v)
+		}
+		return lipgloss.AdaptiveColor{Light: v, Dark: v}, nil
+	case map[string]any:
+		light, lightOK := v["light"].(string)
+		dark, darkOK := v["dark"].(string)
+		if !lightOK || !darkOK || len(v) != 2 {
+			return lipgloss.AdaptiveColor{}, errors.New("expected light and dark colors")
+		}
+		if !validColor(light) || !validColor(dark) {
+			return lipgloss.AdaptiveColor{}, fmt.Errorf("invalid colors %q and %q", light, dark)
+		}
+		return lipgloss.AdaptiveColor{Light: light, Dark: dark}, nil
+	}
+	return lipgloss.AdaptiveColor{}, fmt.Errorf("invalid color %v", value)
+}
+
+// validColor accepts hex colors and ANSI color numbers.
+func validColor(color string) bool {
+	if hexColor.MatchString(color) {
+		return true
+	}
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
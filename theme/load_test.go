@@ -0,0 +1,114 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"json", "ocean.json", `{"name": "ocean", "primary": "#0077B6", "muted": {"light": "#8D99AE", "dark": "#5C677D"}}`},
+		{"toml", "ocean.toml", "name = \"ocean\"\nprimary = \"#0077B6\"\nmuted = { light = \"#8D99AE\", dark = \"#5C677D\" }\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loaded, err := LoadFile(writeFile(t, t.TempDir(), test.file, test.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loaded.Name != "ocean" {
+				t.Errorf("name = %q, want ocean", loaded.Name)
+			}
+			if want := (lipgloss.AdaptiveColor{Light: "#0077B6", Dark: "#0077B6"}); loaded.Primary != want {
+				t.Errorf("primary = %v, want %v", loaded.Primary, want)
+			}
+			if want := (lipgloss.AdaptiveColor{Light: "#8D99AE", Dark: "#5C677D"}); loaded.Muted != want {
+				t.Errorf("muted = %v, want %v", loaded.Muted, want)
+			}
+			if loaded.Danger != Default.Danger {
+				t.Errorf("danger = %v, want the default one", loaded.Danger)
+			}
+		})
+	}
+}
+
+func TestLoadFileDefaultsToFileName(t *testing.T) {
+	loaded, err := LoadFile(writeFile(t, t.TempDir(), "sunset.json", `{"accent": "205"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Name != "sunset" {
+		t.Errorf("name = %q, want sunset", loaded.Name)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		problem string
+	}{
+		{"unsupported format", "ocean.yaml", "primary: red", "unsupported theme file format"},
+		{"syntax", "ocean.json", `{"primary": `, "cannot read theme"},
+		{"unknown token", "ocean.json", `{"background": "#000000"}`, `unknown token "background"`},
+		{"invalid hex color", "ocean.json", `{"primary": "#12345"}`, `invalid color "#12345"`},
+		{"invalid ANSI color", "ocean.json", `{"primary": "256"}`, `invalid color "256"`},
+		{"missing variant", "ocean.json", `{"muted": {"light": "#ffffff"}}`, "expected light and dark colors"},
+		{"extra variant", "ocean.json", `{"muted": {"light": "1", "dark": "2", "dim": "3"}}`, "expected light and dark colors"},
+		{"invalid variant", "ocean.json", `{"muted": {"light": "white", "dark": "2"}}`, "invalid colors"},
+		{"color type", "ocean.json", `{"primary": 12}`, "invalid color 12"},
+		{"name type", "ocean.json", `{"name": 12}`, "name must be a string"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadFile(writeFile(t, t.TempDir(), test.file, test.content))
+			if err == nil || !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("error = %v, want %q", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "load-dir-valid.json", `{"primary": "#000000"}`)
+	writeFile(t, dir, "load-dir-invalid.toml", `primary = "black"`)
+	writeFile(t, dir, "notes.txt", "not a theme")
+
+	loaded, err := LoadDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "load-dir-invalid.toml") {
+		t.Errorf("error = %v, want the invalid file listed", err)
+	}
+	if len(loaded) != 1 || loaded[0].Name != "load-dir-valid" {
+		t.Fatalf("loaded = %v, want the valid theme only", loaded)
+	}
+	if _, ok := Lookup("load-dir-valid"); !ok {
+		t.Error("the valid theme is not registered")
+	}
+}
+
+func TestLoadDirMissing(t *testing.T) {
+	loaded, err := LoadDir(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || loaded != nil {
+		t.Errorf("LoadDir = %v, %v, want nothing", loaded, err)
+	}
+}
@@ -0,0 +1,310 @@
+package preview
+
+import (
+	"github.com/Funkit/theiere/executor"
+	"github.com/Funkit/theiere/fancytext"
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/palette"
+	"github.com/Funkit/theiere/subtable"
+	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/Funkit/theiere/validation"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+// Model is a screen listing the available themes, showing the highlighted one on sample components.
+// Selecting a theme applies it to the whole application with theme.Changed.
+type Model struct {
+	names         []string
+	cursor        int
+	width, height int
+	text          fancytext.Model
+	success       executor.Model
+	failure       executor.Model
+	table         subtable.Model
+	dialog        validation.Model
+	titleStyle    lipgloss.Style
+	itemStyle     lipgloss.Style
+	selectedStyle lipgloss.Style
+	mutedStyle    lipgloss.Style
+	KeyMap        KeyMap
+}
+
+type options struct {
+	width  *int
+	height *int
+}
+
+type Option func(options *options) error
+
+func WithWidth(width int) Option {
+	return func(options *options) error {
+		options.width = &width
+
+		return nil
+	}
+}
+
+func WithHeight(height int) Option {
+	return func(options *options) error {
+		options.height = &height
+
+		return nil
+	}
+}
+
+func New(opts ...Option) (Model, error) {
+	var options options
+	for _, opt := range opts {
+		err := opt(&options)
+		if err != nil {
+			return Model{}, err
+		}
+	}
+
+	width := 100
+	if options.width != nil {
+		width = *options.width
+	}
+
+	height := 30
+	if options.height != nil {
+		height = *options.height
+	}
+
+	text, err := fancytext.New(fancytext.WithContent("Fancy text"))
+	if err != nil {
+		return Model{}, err
+	}
+	text.SetWidth(sampleWidth)
+	text.SetHeight(3)
+
+	success, err := executor.New()
+	if err != nil {
+		return Model{}, err
+	}
+	success.Update(executor.Message{Success: true, Description: "Sample result"})
+
+	failure, err := executor.New()
+	if err != nil {
+		return Model{}, err
+	}
+	failure.Update(executor.Message{Success: false, Description: "Sample error"})
+
+	sample, err := subtable.New(
+		subtable.WithColumns([]table.Column{{Title: "Name", Width: 12}, {Title: "Status", Width: 10}}),
+		subtable.WithRows([]table.Row{{"alpha", "running"}, {"beta", "stopped"}, {"gamma", "running"}}),
+		subtable.WithWidth(sampleWidth),
+	)
+	if err != nil {
+		return Model{}, err
+	}
+	sample.SetHeight(6)
+
+	dialog, err := validation.New()
+	if err != nil {
+		return Model{}, err
+	}
+	dialog.SetWidth(sampleWidth + 16)
+	dialog.SetHeight(9)
+
+	m := Model{
+		names:   theme.Names(),
+		width:   width,
+		height:  height,
+		text:    text,
+		success: success,
+		failure: failure,
+		table:   sample,
+		dialog:  dialog,
+		KeyMap:  DefaultKeyMap(),
+	}
+	m.setStyles(theme.Current())
+	m.Reset()
+
+	return m, nil
+}
+
+func (m *Model) setStyles(t theme.Theme) {
+	m.titleStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Contrast).Background(t.Primary).Padding(0, 1).MarginBottom(1)
+	m.itemStyle = lipgloss.NewStyle().PaddingLeft(2)
+	m.selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
+	m.mutedStyle = lipgloss.NewStyle().Foreground(t.Muted)
+}
+
+// sampleWidth is the width of the sample components.
+const sampleWidth = 30
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.KeyMap.Quit):
+			return m, subview.GoUp
+		case key.Matches(msg, m.KeyMap.Up):
+			m.moveTo(m.cursor - 1)
+		case key.Matches(msg, m.KeyMap.Down):
+			m.moveTo(m.cursor + 1)
+		case key.Matches(msg, m.KeyMap.Apply):
+			if t, ok := m.highlighted(); ok {
+				return m, theme.Use(t)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) View() string {
+	current := theme.Current().Name
+
+	lines := []string{m.titleStyle.Render("Themes")}
+	for i, name := range m.names {
+		line := name
+		if name == current {
+			line += m.mutedStyle.Render(" (current)")
+		}
+		if i == m.cursor {
+			lines = append(lines, m.selectedStyle.Render("› ")+m.selectedStyle.Render(line))
+		} else {
+			lines = append(lines, m.itemStyle.Render(line))
+		}
+	}
+	list := lipgloss.NewStyle().Width(24).Render(strings.Join(lines, "\n"))
+
+	t, _ := m.highlighted()
+	samples := lipgloss.JoinVertical(lipgloss.Left,
+		m.text.View(),
+		"",
+		m.success.View(),
+		m.failure.View(),
+		m.table.View(),
+	)
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, swatches(t), "  ", samples),
+		m.dialog.View(),
+	)
+
+	return lipgloss.NewStyle().MaxWidth(m.width).MaxHeight(m.height).
+		Render(lipgloss.JoinHorizontal(lipgloss.Top, list, right))
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+func (m *Model) SetHeight(height int) {
+	m.height = height
+}
+
+// Reset highlights the current theme, listing the themes registered since the screen was built.
+func (m *Model) Reset() {
+	m.names = theme.Names()
+	m.cursor = 0
+	for i, name := range m.names {
+		if name == theme.Current().Name {
+			m.cursor = i
+		}
+	}
+	m.applySamples()
+}
+
+// SetTheme applies a theme to the screen itself. The samples keep showing the highlighted theme.
+func (m *Model) SetTheme(t theme.Theme) {
+	m.setStyles(t)
+}
+
+// Commands returns a palette command applying each theme.
+func (m *Model) Commands() []palette.Command {
+	var commands []palette.Command
+	for _, name := range theme.Names() {
+		t, _ := theme.Lookup(name)
+		commands = append(commands, palette.Command{
+			ID:       "theme/" + name,
+			Title:    name,
+			Category: "Theme",
+			Run:      theme.Use(t),
+		})
+	}
+	return commands
+}
+
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.AddKeyMap(owner, keys.Component, m.KeyMap)
+}
+
+func (m *Model) KeyHelp() []keys.Group {
+	return []keys.Group{{Title: "Themes", Bindings: m.KeyMap.ShortHelp()}}
+}
+
+func (m *Model) moveTo(cursor int) {
+	if cursor < 0 || cursor >= len(m.names) {
+		return
+	}
+	m.cursor = cursor
+	m.applySamples()
+}
+
+func (m *Model) highlighted() (theme.Theme, bool) {
+	if len(m.names) == 0 {
+		return theme.Current(), false
+	}
+	return theme.Lookup(m.names[m.cursor])
+}
+
+func (m *Model) applySamples() {
+	t, _ := m.highlighted()
+	for _, sample := range []any{&m.text, &m.success, &m.failure, &m.table, &m.dialog} {
+		theme.Apply(sample, t)
+	}
+}
+
+// swatches shows the color of each token of the theme, the general tokens on the left and the component ones on
+// the right.
+func swatches(t theme.Theme) string {
+	type token struct {
+		name  string
+		color lipgloss.AdaptiveColor
+	}
+	columns := [][]token{
+		{
+			{"primary", t.Primary},
+			{"accent", t.Accent},
+			{"success", t.Success},
+			{"danger", t.Danger},
+			{"muted", t.Muted},
+			{"border", t.Border},
+			{"selected", t.Selected},
+			{"contrast", t.Contrast},
+			{"subtle", t.Subtle},
+		},
+		{
+			{"title", t.Title},
+			{"titletext", t.TitleText},
+			{"cursor", t.Cursor},
+			{"cursordesc", t.CursorDesc},
+			{"dimmed", t.Dimmed},
+			{"dimmeddesc", t.DimmedDesc},
+			{"dialog", t.Dialog},
+			{"button", t.Button},
+			{"buttontext", t.ButtonText},
+		},
+	}
+
+	var rendered []string
+	for _, column := range columns {
+		var lines []string
+		for _, token := range column {
+			lines = append(lines, lipgloss.NewStyle().Background(token.color).Render("    ")+" "+token.name)
+		}
+		rendered = append(rendered, strings.Join(lines, "\n"), "  ")
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered[:len(rendered)-1]...)
+}
@@ -0,0 +1,209 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"sort"
+)
+
+// Theme defines the colors of the components by semantic token, each with a light and a dark variant.
+type Theme struct {
+	Name string
+	// Primary fills the main elements, like the background of fancy texts and list titles.
+	Primary lipgloss.AdaptiveColor
+	// Accent highlights the active element of a group, like the focused button of a dialog.
+	Accent lipgloss.AdaptiveColor
+	// Success and Danger show the outcome of an action.
+	Success lipgloss.AdaptiveColor
+	Danger  lipgloss.AdaptiveColor
+	// Muted is used for secondary text, like hints, status lines and table borders.
+	Muted lipgloss.AdaptiveColor
+	// Border surrounds frames, tabs and dialogs.
+	Border lipgloss.AdaptiveColor
+	// Selected is the foreground of the element under the cursor.
+	Selected lipgloss.AdaptiveColor
+	// Contrast is the foreground of text drawn over the Primary, Accent, Success, Danger and Muted backgrounds.
+	Contrast lipgloss.AdaptiveColor
+	// Subtle fills the background around dialogs.
+	Subtle lipgloss.AdaptiveColor
+	// Title fills the background of list titles, like the menu one, and TitleText is the text over it.
+	Title     lipgloss.AdaptiveColor
+	TitleText lipgloss.AdaptiveColor
+	// Cursor is the foreground of the list item under the cursor. CursorDesc is the one of its description and of
+	// the bar marking it.
+	Cursor     lipgloss.AdaptiveColor
+	CursorDesc lipgloss.AdaptiveColor
+	// Dimmed is the foreground of the disabled list items, and of all of them while a filter is typed.
+	// DimmedDesc is the one of their descriptions.
+	Dimmed     lipgloss.AdaptiveColor
+	DimmedDesc lipgloss.AdaptiveColor
+	// Dialog surrounds dialogs. Button fills their inactive buttons, and ButtonText is the text of all their buttons.
+	Dialog     lipgloss.AdaptiveColor
+	Button     lipgloss.AdaptiveColor
+	ButtonText lipgloss.AdaptiveColor
+}
+
+var (
+	Default = Theme{
+		Name:       "default",
+		Primary:    lipgloss.AdaptiveColor{Light: "#7D56F4", Dark: "#7D56F4"},
+		Accent:     lipgloss.AdaptiveColor{Light: "#F25D94", Dark: "#F25D94"},
+		Success:    lipgloss.AdaptiveColor{Light: "#6AA84F", Dark: "#6AA84F"},
+		Danger:     lipgloss.AdaptiveColor{Light: "#F44336", Dark: "#F44336"},
+		Muted:      lipgloss.AdaptiveColor{Light: "240", Dark: "240"},
+		Border:     lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"},
+		Selected:   lipgloss.AdaptiveColor{Light: "212", Dark: "212"},
+		Contrast:   lipgloss.AdaptiveColor{Light: "#FAFAFA", Dark: "#FAFAFA"},
+		Subtle:     lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#383838"},
+		Title:      lipgloss.AdaptiveColor{Light: "62", Dark: "62"},
+		TitleText:  lipgloss.AdaptiveColor{Light: "230", Dark: "230"},
+		Cursor:     lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"},
+		CursorDesc: lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"},
+		Dimmed:     lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"},
+		DimmedDesc: lipgloss.AdaptiveColor{Light: "#C2B8C2", Dark: "#4D4D4D"},
+		Dialog:     lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#874BFD"},
+		Button:     lipgloss.AdaptiveColor{Light: "#888B7E", Dark: "#888B7E"},
+		ButtonText: lipgloss.AdaptiveColor{Light: "#FFF7DB", Dark: "#FFF7DB"},
+	}
+
+	Dracula = Theme{
+		Name:       "dracula",
+		Primary:    lipgloss.AdaptiveColor{Light: "#7C5CC4", Dark: "#BD93F9"},
+		Accent:     lipgloss.AdaptiveColor{Light: "#C2479A", Dark: "#FF79C6"},
+		Success:    lipgloss.AdaptiveColor{Light: "#2E9E4F", Dark: "#50FA7B"},
+		Danger:     lipgloss.AdaptiveColor{Light: "#D63C3C", Dark: "#FF5555"},
+		Muted:      lipgloss.AdaptiveColor{Light: "#7A7F99", Dark: "#6272A4"},
+		Border:     lipgloss.AdaptiveColor{Light: "#7C5CC4", Dark: "#BD93F9"},
+		Selected:   lipgloss.AdaptiveColor{Light: "#C2479A", Dark: "#FF79C6"},
+		Contrast:   lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#282A36"},
+		Subtle:     lipgloss.AdaptiveColor{Light: "#E6E6F0", Dark: "#44475A"},
+		Title:      lipgloss.AdaptiveColor{Light: "#7C5CC4", Dark: "#BD93F9"},
+		TitleText:  lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#282A36"},
+		Cursor:     lipgloss.AdaptiveColor{Light: "#C2479A", Dark: "#FF79C6"},
+		CursorDesc: lipgloss.AdaptiveColor{Light: "#C2479A", Dark: "#FF79C6"},
+		Dimmed:     lipgloss.AdaptiveColor{Light: "#7A7F99", Dark: "#6272A4"},
+		DimmedDesc: lipgloss.AdaptiveColor{Light: "#7A7F99", Dark: "#6272A4"},
+		Dialog:     lipgloss.AdaptiveColor{Light: "#7C5CC4", Dark: "#BD93F9"},
+		Button:     lipgloss.AdaptiveColor{Light: "#7A7F99", Dark: "#6272A4"},
+		ButtonText: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#282A36"},
+	}
+
+	Nord = Theme{
+		Name:       "nord",
+		Primary:    lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+		Accent:     lipgloss.AdaptiveColor{Light: "#B48EAD", Dark: "#B48EAD"},
+		Success:    lipgloss.AdaptiveColor{Light: "#6E9C56", Dark: "#A3BE8C"},
+		Danger:     lipgloss.AdaptiveColor{Light: "#BF616A", Dark: "#BF616A"},
+		Muted:      lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#616E88"},
+		Border:     lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#81A1C1"},
+		Selected:   lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+		Contrast:   lipgloss.AdaptiveColor{Light: "#ECEFF4", Dark: "#2E3440"},
+		Subtle:     lipgloss.AdaptiveColor{Light: "#E5E9F0", Dark: "#3B4252"},
+		Title:      lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+		TitleText:  lipgloss.AdaptiveColor{Light: "#ECEFF4", Dark: "#2E3440"},
+		Cursor:     lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+		CursorDesc: lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"},
+		Dimmed:     lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#616E88"},
+		DimmedDesc: lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#616E88"},
+		Dialog:     lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#81A1C1"},
+		Button:     lipgloss.AdaptiveColor{Light: "#7B88A1", Dark: "#616E88"},
+		ButtonText: lipgloss.AdaptiveColor{Light: "#ECEFF4", Dark: "#2E3440"},
+	}
+
+	Monochrome = Theme{
+		Name:       "monochrome",
+		Primary:    lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Accent:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Success:    lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+		Danger:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Muted:      lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+		Border:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Selected:   lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Contrast:   lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+		Subtle:     lipgloss.AdaptiveColor{Light: "7", Dark: "8"},
+		Title:      lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		TitleText:  lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+		Cursor:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		CursorDesc: lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Dimmed:     lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+		DimmedDesc: lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+		Dialog:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Button:     lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+		ButtonText: lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+	}
+
+	// HighContrast draws text and borders in plain black or white, with the basic terminal colors for states.
+	HighContrast = Theme{
+		Name:       "high-contrast",
+		Primary:    lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Accent:     lipgloss.AdaptiveColor{Light: "4", Dark: "11"},
+		Success:    lipgloss.AdaptiveColor{Light: "2", Dark: "10"},
+		Danger:     lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+		Muted:      lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Border:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Selected:   lipgloss.AdaptiveColor{Light: "4", Dark: "11"},
+		Contrast:   lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+		Subtle:     lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+		Title:      lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		TitleText:  lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+		Cursor:     lipgloss.AdaptiveColor{Light: "4", Dark: "11"},
+		CursorDesc: lipgloss.AdaptiveColor{Light: "4", Dark: "11"},
+		Dimmed:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		DimmedDesc: lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Dialog:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		Button:     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+		ButtonText: lipgloss.AdaptiveColor{Light: "15", Dark: "0"},
+	}
+)
+
+var (
+	current = Default
+	// themes holds the built-in themes and the registered ones, by name.
+	themes = map[string]Theme{}
+)
+
+func init() {
+	for _, t := range []Theme{Default, Dracula, Nord, Monochrome, HighContrast} {
+		themes[t.Name] = t
+	}
+}
+
+// Set sets the theme used by the components built afterwards. It is meant to be called once, at the root of the
+// application. Use Changed to switch the theme of the components already built.
+func Set(t Theme) {
+	current = t
+}
+
+// Current returns the theme in use, the default one unless Set was called.
+func Current() Theme {
+	return colored(current)
+}
+
+// colored returns the theme as rendered with the accessibility settings: without any color when NoColor is set.
+func colored(t Theme) Theme {
+	if !access.NoColor {
+		return t
+	}
+	// Empty colors are not rendered.
+	return Theme{Name: t.Name}
+}
+
+// Register makes a theme available by name, replacing any theme with the same name.
+func Register(t Theme) {
+	themes[t.Name] = t
+}
+
+// Lookup returns a built-in or registered theme by name.
+func Lookup(name string) (Theme, bool) {
+	t, ok := themes[name]
+	return t, ok
+}
+
+// Names returns the names of the built-in and registered themes.
+func Names() []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
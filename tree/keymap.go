@@ -0,0 +1,109 @@
+package tree
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines keybindings. It satisfies the help.KeyMap interface.
+type KeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Expand   key.Binding
+	Collapse key.Binding
+	Select   key.Binding
+	Mark     key.Binding
+
+	// Keybindings used for searching.
+	Search    key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+
+	// The quit keybinding. This won't be caught when searching.
+	Quit key.Binding
+}
+
+func init() {
+	keys.Define("tree", KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
+func DefaultKeyMap() KeyMap {
+	return keys.Apply("tree", KeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Expand: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "expand"),
+		),
+		Collapse: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "collapse"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "prev match"),
+		),
+		// Quitting.
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q", "quit"),
+		),
+	})
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Up,
+		k.Down,
+		k.Expand,
+		k.Collapse,
+		k.Search,
+		k.Quit,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{
+			k.Up,
+			k.Down,
+			k.Expand,
+			k.Collapse,
+		},
+		{
+			k.Select,
+			k.Mark,
+		},
+		{
+			k.Search,
+			k.NextMatch,
+			k.PrevMatch,
+		},
+		{
+			k.Quit,
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package tree
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// DeclareKeys declares the bindings of the tree. The mark binding is declared only with multi-selection.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	k := m.KeyMap
+	registry.Add(owner, keys.Component,
+		k.Up, k.Down, k.Expand, k.Collapse, k.Select,
+		k.Search, k.NextMatch, k.PrevMatch, k.Quit,
+	)
+	if m.multiSelect {
+		registry.Add(owner, keys.Component, k.Mark)
+	}
+}
+
+// CapturingInput tells whether the search query is being typed.
+func (m *Model) CapturingInput() bool {
+	return m.searching
+}
+
+// KeyHelp returns the bindings of the tree, or the ones of the search prompt while the query is typed.
+func (m *Model) KeyHelp() []keys.Group {
+	if m.searching {
+		return []keys.Group{{Title: "Search", Bindings: []key.Binding{searchBinding, cancelBinding}}}
+	}
+
+	k := m.KeyMap
+	bindings := []key.Binding{k.Up, k.Down, k.Expand, k.Collapse, k.Select}
+	if m.multiSelect {
+		bindings = append(bindings, k.Mark)
+	}
+	bindings = append(bindings, k.Search)
+	if m.query != "" {
+		bindings = append(bindings, k.NextMatch, k.PrevMatch)
+	}
+	return []keys.Group{{Title: "Tree", Bindings: append(bindings, k.Quit)}}
+}
+
+var (
+	searchBinding = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "search"))
+	cancelBinding = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel"))
+)
@@ -0,0 +1,99 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/Funkit/theiere/subview"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+// updateSearch handles the messages received while the search query is being typed.
+func (m *Model) updateSearch(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.searching = false
+			m.searchInput.Blur()
+			m.search(strings.TrimSpace(m.searchInput.Value()))
+			return m, nil
+		case tea.KeyEsc:
+			m.searching = false
+			m.searchInput.Blur()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return m, cmd
+}
+
+// search expands the loaded nodes down to the ones whose title contains the query,
+// and moves the cursor to the first of them.
+func (m *Model) search(query string) {
+	m.query = query
+	m.matches = nil
+	if query == "" {
+		return
+	}
+
+	var path []*Node
+	var visit func(nodes []*Node)
+	visit = func(nodes []*Node) {
+		for _, node := range nodes {
+			if strings.Contains(strings.ToLower(node.Title), strings.ToLower(query)) {
+				m.matches = append(m.matches, node)
+				for _, ancestor := range path {
+					ancestor.Expanded = true
+				}
+			}
+			path = append(path, node)
+			visit(node.Children)
+			path = path[:len(path)-1]
+		}
+	}
+	visit(m.roots)
+
+	if len(m.matches) > 0 {
+		m.refresh(m.matches[0])
+	}
+}
+
+// jumpToMatch moves the cursor to the next (or previous) match of the search.
+func (m *Model) jumpToMatch(direction int) {
+	if len(m.matches) == 0 {
+		return
+	}
+
+	current := -1
+	for i, match := range m.matches {
+		if match == m.SelectedNode() {
+			current = i
+		}
+	}
+
+	next := (current + direction + len(m.matches)) % len(m.matches)
+	if current < 0 && direction < 0 {
+		next = len(m.matches) - 1
+	}
+	m.refresh(m.matches[next])
+}
+
+func (m *Model) matchCount() string {
+	if len(m.matches) == 1 {
+		return fmt.Sprintf("1 match for %q", m.query)
+	}
+	return fmt.Sprintf("%d matches for %q", len(m.matches), m.query)
+}
+
+// highlight renders the title with a style, the first occurrence of the query being underlined. The text before
+// the match, the match and the text after it are rendered separately, so that the style applies to all of them.
+func highlight(title, query string, style lipgloss.Style) string {
+	i := strings.Index(strings.ToLower(title), strings.ToLower(query))
+	if query == "" || i < 0 || len(strings.ToLower(title)) != len(title) {
+		return style.Render(title)
+	}
+	before, match, after := title[:i], title[i:i+len(query)], title[i+len(query):]
+	return style.Render(before) + style.Copy().Inherit(matchStyle).Render(match) + style.Render(after)
+}
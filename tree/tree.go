@@ -0,0 +1,488 @@
+package tree
+
+import (
+	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+)
+
+var matchStyle = lipgloss.NewStyle().Underline(true)
+
+// Node is an element of the tree.
+type Node struct {
+	ID    string
+	Title string
+	// Kind is used to pick the icon displayed before the title.
+	Kind     string
+	Children []*Node
+	// HasChildren marks a node whose children are not known yet. They are requested
+	// from the loader the first time the node is expanded.
+	HasChildren bool
+	Expanded    bool
+	// Value holds user data attached to the node.
+	Value   any
+	loading bool
+}
+
+func (n *Node) expandable() bool {
+	return len(n.Children) > 0 || n.HasChildren
+}
+
+// ChildrenLoaded must be returned by the command of the loader once the children of a node are available.
+type ChildrenLoaded struct {
+	ID       string
+	Children []*Node
+	Err      error
+}
+
+// Selected is sent when a node without children is selected.
+type Selected struct {
+	Node *Node
+}
+
+// Loader returns the command loading the children of a node, which must result in a ChildrenLoaded message.
+type Loader func(node *Node) tea.Cmd
+
+// Model is a tree of expandable nodes, for file systems or configuration hierarchies.
+// Leaving the tree returns subview.TreeUp as a tea.Msg.
+type Model struct {
+	roots         []*Node
+	lines         []line
+	cursor        int
+	offset        int
+	width, height int
+	loader        Loader
+	icons         map[string]string
+	multiSelect   bool
+	marked        map[*Node]bool
+	searchInput   textinput.Model
+	searching     bool
+	query         string
+	matches       []*Node
+	status        string
+	fixedSize     bool
+	guideStyle    lipgloss.Style
+	statusStyle   lipgloss.Style
+	selectedStyle lipgloss.Style
+	KeyMap        KeyMap
+}
+
+type line struct {
+	node   *Node
+	depth  int
+	prefix string
+}
+
+type options struct {
+	width       *int
+	height      *int
+	loader      Loader
+	icons       map[string]string
+	multiSelect bool
+	fixedSize   bool
+	keyMap      *KeyMap
+}
+
+type Option func(options *options) error
+
+// WithKeyMap replaces the default key bindings.
+func WithKeyMap(keyMap KeyMap) Option {
+	return func(options *options) error {
+		options.keyMap = &keyMap
+
+		return nil
+	}
+}
+
+func WithWidth(width int) Option {
+	return func(options *options) error {
+		options.width = &width
+
+		return nil
+	}
+}
+
+func WithHeight(height int) Option {
+	return func(options *options) error {
+		options.height = &height
+
+		return nil
+	}
+}
+
+func WithFixedSize() Option {
+	return func(options *options) error {
+		options.fixedSize = true
+
+		return nil
+	}
+}
+
+// WithLoader sets the function loading the children of the nodes marked with HasChildren.
+func WithLoader(loader Loader) Option {
+	return func(options *options) error {
+		options.loader = loader
+
+		return nil
+	}
+}
+
+// WithIcons sets the icon displayed before the title of the nodes, by node kind.
+func WithIcons(icons map[string]string) Option {
+	return func(options *options) error {
+		options.icons = icons
+
+		return nil
+	}
+}
+
+// WithMultiSelect allows marking several nodes, retrieved with Marked.
+func WithMultiSelect() Option {
+	return func(options *options) error {
+		options.multiSelect = true
+
+		return nil
+	}
+}
+
+func New(roots []*Node, opts ...Option) (Model, error) {
+	var options options
+	for _, opt := range opts {
+		err := opt(&options)
+		if err != nil {
+			return Model{}, err
+		}
+	}
+
+	width := 50
+	if options.width != nil {
+		width = *options.width
+	}
+
+	height := 20
+	if options.height != nil {
+		height = *options.height
+	}
+
+	searchInput := textinput.New()
+	searchInput.Prompt = "/"
+
+	keyMap := DefaultKeyMap()
+	if options.keyMap != nil {
+		keyMap = *options.keyMap
+	}
+
+	m := Model{
+		roots:       roots,
+		width:       width,
+		height:      height,
+		loader:      options.loader,
+		icons:       options.icons,
+		multiSelect: options.multiSelect,
+		marked:      make(map[*Node]bool),
+		searchInput: searchInput,
+		fixedSize:   options.fixedSize,
+		KeyMap:      keyMap,
+	}
+	m.setStyles(theme.Current())
+	m.refresh(nil)
+
+	return m, nil
+}
+
+func (m *Model) setStyles(t theme.Theme) {
+	m.guideStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	m.statusStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	m.selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Selected)
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
+	if m.searching {
+		return m.updateSearch(msg)
+	}
+
+	switch msg := msg.(type) {
+	case ChildrenLoaded:
+		m.childrenLoaded(msg)
+		return m, nil
+	case tea.KeyMsg:
+		m.status = ""
+		node := m.SelectedNode()
+		switch {
+		case key.Matches(msg, m.KeyMap.Quit):
+			return m, subview.GoUp
+		case key.Matches(msg, m.KeyMap.Up):
+			m.moveTo(m.cursor - 1)
+		case key.Matches(msg, m.KeyMap.Down):
+			m.moveTo(m.cursor + 1)
+		case key.Matches(msg, m.KeyMap.Search):
+			m.searching = true
+			m.searchInput.SetValue(m.query)
+			m.searchInput.CursorEnd()
+			return m, m.searchInput.Focus()
+		case key.Matches(msg, m.KeyMap.NextMatch):
+			m.jumpToMatch(1)
+		case key.Matches(msg, m.KeyMap.PrevMatch):
+			m.jumpToMatch(-1)
+		case node == nil:
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Expand):
+			if node.expandable() && !node.Expanded {
+				return m, m.expand(node)
+			}
+		case key.Matches(msg, m.KeyMap.Collapse):
+			if node.Expanded {
+				node.Expanded = false
+				m.refresh(node)
+			} else if parent := m.parentLine(m.cursor); parent >= 0 {
+				m.moveTo(parent)
+			}
+		case key.Matches(msg, m.KeyMap.Mark) && m.multiSelect:
+			if m.marked[node] {
+				delete(m.marked, node)
+			} else {
+				m.marked[node] = true
+			}
+			m.moveTo(m.cursor + 1)
+		case key.Matches(msg, m.KeyMap.Select):
+			if node.expandable() {
+				if node.Expanded {
+					node.Expanded = false
+					m.refresh(node)
+					return m, nil
+				}
+				return m, m.expand(node)
+			}
+			return m, func() tea.Msg { return Selected{Node: node} }
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) View() string {
+	var rendered []string
+	end := min(m.offset+m.visibleHeight(), len(m.lines))
+	for i := m.offset; i < end; i++ {
+		rendered = append(rendered, m.renderLine(i))
+	}
+
+	footer := ""
+	switch {
+	case m.searching:
+		footer = m.searchInput.View()
+	case m.status != "":
+		footer = m.statusStyle.Render(m.status)
+	case m.query != "":
+		footer = m.statusStyle.Render(m.matchCount())
+	}
+
+	content := lipgloss.NewStyle().Width(m.width).Height(m.visibleHeight()).MaxWidth(m.width).Render(strings.Join(rendered, "\n"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, content, footer)
+}
+
+func (m *Model) SetWidth(width int) {
+	if !m.fixedSize {
+		m.width = width
+	}
+}
+
+func (m *Model) SetHeight(height int) {
+	if !m.fixedSize {
+		m.height = height
+		m.moveTo(m.cursor)
+	}
+}
+
+func (m *Model) Reset() {
+	m.searching = false
+	m.searchInput.Blur()
+	m.query = ""
+	m.matches = nil
+	m.status = ""
+	m.cursor = 0
+	m.offset = 0
+}
+
+// SelectedNode returns the node under the cursor, if any.
+func (m *Model) SelectedNode() *Node {
+	if len(m.lines) == 0 {
+		return nil
+	}
+	return m.lines[m.cursor].node
+}
+
+// Marked returns the nodes marked when multi-selection is enabled, in tree order.
+func (m *Model) Marked() []*Node {
+	var marked []*Node
+	walk(m.roots, func(n *Node) {
+		if m.marked[n] {
+			marked = append(marked, n)
+		}
+	})
+	return marked
+}
+
+func (m *Model) expand(node *Node) tea.Cmd {
+	node.Expanded = true
+	var cmd tea.Cmd
+	if len(node.Children) == 0 && node.HasChildren && m.loader != nil && !node.loading {
+		node.loading = true
+		cmd = m.loader(node)
+	}
+	m.refresh(node)
+	return cmd
+}
+
+func (m *Model) childrenLoaded(msg ChildrenLoaded) {
+	node := find(m.roots, msg.ID)
+	if node == nil {
+		return
+	}
+	node.loading = false
+	if msg.Err != nil {
+		node.Expanded = false
+		m.status = "Loading failed: " + msg.Err.Error()
+	} else {
+		node.Children = msg.Children
+		node.HasChildren = len(msg.Children) > 0
+	}
+	m.refresh(m.SelectedNode())
+}
+
+// refresh flattens the expanded nodes and keeps the cursor on the given node.
+func (m *Model) refresh(selected *Node) {
+	m.lines = m.lines[:0]
+	flatten(m.roots, 0, "", &m.lines)
+
+	cursor := 0
+	for i, l := range m.lines {
+		if l.node == selected {
+			cursor = i
+		}
+	}
+	m.moveTo(cursor)
+}
+
+func (m *Model) moveTo(cursor int) {
+	m.cursor = max(0, min(cursor, len(m.lines)-1))
+
+	height := m.visibleHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.lines)-height))
+}
+
+// visibleHeight is the number of lines available for nodes, one line being kept for the footer.
+func (m *Model) visibleHeight() int {
+	return max(1, m.height-1)
+}
+
+func (m *Model) parentLine(index int) int {
+	depth := m.lines[index].depth
+	for i := index - 1; i >= 0; i-- {
+		if m.lines[i].depth < depth {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Model) renderLine(index int) string {
+	l := m.lines[index]
+	node := l.node
+
+	marker := "  "
+	switch {
+	case node.loading:
+		marker = "… "
+	case node.expandable() && node.Expanded:
+		marker = "▾ "
+	case node.expandable():
+		marker = "▸ "
+	}
+
+	check := ""
+	if m.multiSelect {
+		check = "[ ] "
+		if m.marked[node] {
+			check = "[x] "
+		}
+	}
+
+	style := lipgloss.NewStyle()
+	if index == m.cursor {
+		style = m.selectedStyle
+	}
+
+	text := check + marker
+	if icon, ok := m.icons[node.Kind]; ok {
+		text += icon + " "
+	}
+
+	return m.guideStyle.Render(l.prefix) + style.Render(text) + highlight(node.Title, m.query, style)
+}
+
+// flatten lists the visible nodes, computing the guide lines drawn before each of them.
+func flatten(nodes []*Node, depth int, indent string, lines *[]line) {
+	for i, node := range nodes {
+		last := i == len(nodes)-1
+
+		branch, childIndent := "├── ", indent+"│   "
+		if last {
+			branch, childIndent = "└── ", indent+"    "
+		}
+
+		*lines = append(*lines, line{node: node, depth: depth, prefix: indent + branch})
+		if node.Expanded {
+			flatten(node.Children, depth+1, childIndent, lines)
+		}
+	}
+}
+
+func walk(nodes []*Node, fn func(n *Node)) {
+	for _, node := range nodes {
+		fn(node)
+		walk(node.Children, fn)
+	}
+}
+
+func find(nodes []*Node, id string) *Node {
+	var found *Node
+	walk(nodes, func(n *Node) {
+		if found == nil && n.ID == id {
+			found = n
+		}
+	})
+	return found
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (m *Model) SetTheme(t theme.Theme) {
+	m.setStyles(t)
+}
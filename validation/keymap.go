@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"github.com/Funkit/theiere/keys"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines keybindings. It satisfies the help.KeyMap interface.
+type KeyMap struct {
+	Switch  key.Binding
+	Confirm key.Binding
+	Back    key.Binding
+}
+
+func init() {
+	keys.Define("validation", KeyMap{})
+}
+
+// DefaultKeyMap returns a default set of keybindings, overridden by the key binding configuration in use.
+func DefaultKeyMap() KeyMap {
+	return keys.Apply("validation", KeyMap{
+		Switch: key.NewBinding(
+			key.WithKeys("left", "right"),
+			key.WithHelp("←/→", "switch"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q", "back"),
+		),
+	})
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Switch,
+		k.Confirm,
+		k.Back,
+	}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{
+		k.Switch,
+		k.Confirm,
+		k.Back,
+	}}
+}
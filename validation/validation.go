@@ -2,34 +2,14 @@ package validation
 
 import (
 	"github.com/Funkit/theiere/executor"
+	"github.com/Funkit/theiere/keys"
+	"github.com/Funkit/theiere/mouse"
 	"github.com/Funkit/theiere/subview"
+	"github.com/Funkit/theiere/theme"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
-
-var (
-	subtle = lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#383838"}
-
-	dialogBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
-			Padding(1, 0).
-			BorderTop(true).
-			BorderLeft(true).
-			BorderRight(true).
-			BorderBottom(true)
-
-	buttonStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFF7DB")).
-			Background(lipgloss.Color("#888B7E")).
-			Padding(0, 3).
-			MarginTop(1).MarginRight(1)
-
-	activeButtonStyle = buttonStyle.Copy().
-				Foreground(lipgloss.Color("#FFF7DB")).
-				Background(lipgloss.Color("#F25D94")).
-				MarginTop(1).
-				Underline(true)
+	"math"
 )
 
 type Model struct {
@@ -40,6 +20,12 @@ type Model struct {
 	execEnabled   bool
 	//this channel must be initialized outside this model
 	clientCom chan<- struct{}
+	KeyMap    KeyMap
+	// The dialog styles, applied from the theme.
+	dialogBoxStyle    lipgloss.Style
+	buttonStyle       lipgloss.Style
+	activeButtonStyle lipgloss.Style
+	subtle            lipgloss.AdaptiveColor
 }
 
 type options struct {
@@ -98,14 +84,38 @@ func New(opts ...Option) (Model, error) {
 		return Model{}, err
 	}
 
-	return Model{
+	m := Model{
 		width:     width,
 		height:    height,
 		exec:      exec,
 		clientCom: options.clientCom,
+		KeyMap:    DefaultKeyMap(),
 		frameStyle: lipgloss.NewStyle().
 			AlignHorizontal(lipgloss.Center).AlignVertical(lipgloss.Center),
-	}, nil
+	}
+	m.setStyles(theme.Current())
+
+	return m, nil
+}
+
+func (m *Model) setStyles(t theme.Theme) {
+	m.dialogBoxStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Dialog).
+		Padding(1, 0).
+		BorderTop(true).
+		BorderLeft(true).
+		BorderRight(true).
+		BorderBottom(true)
+	m.buttonStyle = lipgloss.NewStyle().
+		Foreground(t.ButtonText).
+		Background(t.Button).
+		Padding(0, 3).
+		MarginTop(1).MarginRight(1)
+	m.activeButtonStyle = m.buttonStyle.Copy().
+		Background(t.Accent).
+		Underline(true)
+	m.subtle = t.Subtle
 }
 
 func (m *Model) Init() tea.Cmd { return nil }
@@ -113,10 +123,10 @@ func (m *Model) Init() tea.Cmd { return nil }
 func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "left", "right":
+		switch {
+		case key.Matches(msg, m.KeyMap.Switch):
 			m.buttonPos = !m.buttonPos
-		case "enter":
+		case key.Matches(msg, m.KeyMap.Confirm):
 			if m.execEnabled {
 
 				recv, cmd := m.exec.Update(msg)
@@ -126,43 +136,84 @@ func (m *Model) Update(msg tea.Msg) (subview.Model, tea.Cmd) {
 
 				return m, cmd
 			}
-			if !m.buttonPos {
-				return m, subview.GoUp
-			}
-			if m.clientCom != nil {
-				m.clientCom <- struct{}{}
-			}
-			return m, Proceed()
-		case "q", "esc":
+			return m, m.confirm()
+		case key.Matches(msg, m.KeyMap.Back):
 			return m, subview.GoUp
 		}
+	case tea.MouseMsg:
+		if m.execEnabled || msg.Type != tea.MouseLeft {
+			break
+		}
+		if yes, ok := m.buttonAt(msg); ok {
+			m.buttonPos = yes
+			return m, m.confirm()
+		}
 	}
 	return m, nil
 }
 
-func (m *Model) View() string {
-	okButton := ""
-	cancelButton := ""
-	if m.buttonPos {
-		okButton = activeButtonStyle.Render("Yes")
-		cancelButton = buttonStyle.Render("No")
-	} else {
-		okButton = buttonStyle.Render("Yes")
-		cancelButton = activeButtonStyle.Render("No")
+// confirm validates the choice of the active button.
+func (m *Model) confirm() tea.Cmd {
+	if !m.buttonPos {
+		return subview.GoUp
+	}
+	if m.clientCom != nil {
+		m.clientCom <- struct{}{}
 	}
+	return Proceed()
+}
 
-	question := lipgloss.NewStyle().Width(40).MarginBottom(1).Align(lipgloss.Center).Render("Do you confirm your choice ?")
-	buttons := lipgloss.JoinHorizontal(lipgloss.Center, okButton, cancelButton)
-	ui := lipgloss.JoinVertical(lipgloss.Center, question, buttons)
+func (m *Model) View() string {
+	okButton, cancelButton := m.renderButtons()
 
 	return lipgloss.Place(m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
-		dialogBoxStyle.Render(ui),
+		m.dialogBoxStyle.Render(dialogContent(okButton, cancelButton)),
 		lipgloss.WithWhitespaceChars("/"),
-		lipgloss.WithWhitespaceForeground(subtle),
+		lipgloss.WithWhitespaceForeground(m.subtle),
 	)
 }
 
+func (m *Model) renderButtons() (okButton, cancelButton string) {
+	if m.buttonPos {
+		return m.activeButtonStyle.Render(theme.Marked("Yes", true)), m.buttonStyle.Render(theme.Marked("No", false))
+	}
+	return m.buttonStyle.Render(theme.Marked("Yes", false)), m.activeButtonStyle.Render(theme.Marked("No", true))
+}
+
+// dialogContent lays out the question above the buttons.
+func dialogContent(okButton, cancelButton string) string {
+	question := lipgloss.NewStyle().Width(40).MarginBottom(1).Align(lipgloss.Center).Render("Do you confirm your choice ?")
+	buttons := lipgloss.JoinHorizontal(lipgloss.Center, okButton, cancelButton)
+	return lipgloss.JoinVertical(lipgloss.Center, question, buttons)
+}
+
+// buttonAt tells which button is drawn at the position of the event, yes being true for the Yes button.
+func (m *Model) buttonAt(msg tea.MouseMsg) (yes, ok bool) {
+	okButton, cancelButton := m.renderButtons()
+	content := dialogContent(okButton, cancelButton)
+	box := m.dialogBoxStyle
+	boxWidth, boxHeight := mouse.Size(box.Render(content))
+
+	// The dialog is centered, and its buttons are centered below the question by JoinVertical, which rounds
+	// the left gap up. The buttons are drawn on their last line, below their top margin.
+	contentWidth, contentHeight := mouse.Size(content)
+	buttonsWidth := lipgloss.Width(okButton) + lipgloss.Width(cancelButton)
+	x := mouse.Offset(m.width, boxWidth, lipgloss.Center) + box.GetBorderLeftSize() + box.GetPaddingLeft() +
+		int(math.Round(float64(contentWidth-buttonsWidth)/2))
+	y := mouse.Offset(m.height, boxHeight, lipgloss.Center) + box.GetBorderTopWidth() + box.GetPaddingTop() + contentHeight - 1
+
+	okWidth := lipgloss.Width(okButton) - m.buttonStyle.GetMarginRight()
+	cancelWidth := lipgloss.Width(cancelButton) - m.buttonStyle.GetMarginRight()
+	switch {
+	case mouse.In(msg, x, y, okWidth, 1):
+		return true, true
+	case mouse.In(msg, x+lipgloss.Width(okButton), y, cancelWidth, 1):
+		return false, true
+	}
+	return false, false
+}
+
 type Status struct {
 	Validated bool
 }
@@ -186,3 +237,18 @@ func (m *Model) Reset() {
 	m.execEnabled = false
 	m.exec.Reset()
 }
+
+// DeclareKeys declares the bindings switching between the buttons, confirming and going back.
+func (m *Model) DeclareKeys(registry *keys.Registry, owner string) {
+	registry.AddKeyMap(owner, keys.Component, m.KeyMap)
+}
+
+func (m *Model) KeyHelp() []keys.Group {
+	return []keys.Group{{Title: "Confirmation", Bindings: m.KeyMap.ShortHelp()}}
+}
+
+// SetTheme applies a theme to the dialog, and to the executor displayed once validated.
+func (m *Model) SetTheme(t theme.Theme) {
+	m.setStyles(t)
+	m.exec.SetTheme(t)
+}